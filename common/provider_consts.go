@@ -6,6 +6,8 @@ const (
 	DefaultStorageEndpoint = "storage.yandexcloud.net"
 	DefaultYMQEndpoint     = "message-queue.api.cloud.yandex.net"
 	DefaultRegion          = "ru-central1"
+
+	DefaultTokenExchangeEndpoint = "https://auth.yandex.cloud/oauth/token"
 )
 
 var Descriptions = map[string]string{
@@ -33,6 +35,63 @@ var Descriptions = map[string]string{
 	"max_retries": "The maximum number of times an API request is being executed. \n" +
 		"If the API request still fails, an error is thrown.",
 
+	"retry": "Retry policy of API calls. Settings not specified here keep their default values.",
+
+	"retry_codes": "gRPC status codes the API call is retried on, e.g. `UNAVAILABLE`, `RESOURCE_EXHAUSTED`, `INTERNAL`. \n" +
+		"Default is `UNAVAILABLE`.",
+
+	"retry_base_delay": "Base delay of exponential backoff between retries, e.g. `50ms`. Default is `50ms`.",
+
+	"retry_max_delay": "Maximum delay between retries, e.g. `30s`. Default is `1m`.",
+
+	"retry_max_retries": "The maximum number of times an API request is being executed. Default is the value of `max_retries`.",
+
+	"retry_operation_poll": "Retry settings of polls made while waiting for long-running operations to complete. \n" +
+		"Settings not specified here are taken from the `retry` block, except `codes` which default to \n" +
+		"`UNAVAILABLE`, `RESOURCE_EXHAUSTED` and `INTERNAL`.",
+
+	"retry_service": "Retry settings of calls to a particular service. Settings not specified here are taken from the `retry` block.",
+
+	"endpoints": "Custom addresses of individual services, keyed by service ID, e.g. `compute`. \n" +
+		"Address is `host:port`, optionally prefixed with `grpc://` for plaintext connection or `grpcs://` for TLS one.",
+
+	"ca_file": "Path to a PEM file with CA certificates trusted in addition to system ones when connecting to the API endpoints. \n" +
+		"Can't be used together with `ca_content`.",
+
+	"ca_content": "PEM encoded CA certificates trusted in addition to system ones when connecting to the API endpoints. \n" +
+		"Can't be used together with `ca_file`.",
+
+	"http_proxy": "URL of HTTP proxy to connect to the API endpoints, Object Storage, Message Queue and YDB through. \n" +
+		"By default proxy is taken from `HTTPS_PROXY` environment variable.",
+
+	"no_proxy": "Comma-separated list of hosts, domains and CIDRs to connect to directly, bypassing `http_proxy`.",
+
+	"audit_log_file": "Path to a file to append records of every mutating API call to, in JSON Lines format. \n" +
+		"Object Storage S3 API and Message Queue API calls are recorded as well.",
+
+	"tracing": "Export OpenTelemetry traces of Terraform operations and API calls over OTLP. \n" +
+		"Tracing can also be enabled by setting `OTEL_TRACES_EXPORTER=otlp` environment variable.",
+
+	"tracing_otlp_endpoint": "OTLP gRPC endpoint to export traces to, e.g. `localhost:4317`. \n" +
+		"Default is taken from `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT` or `OTEL_EXPORTER_OTLP_ENDPOINT` environment variables.",
+
+	"tracing_insecure": "Disable use of TLS when exporting traces. Default value is `false`.",
+
+	"rate_limit": "Client-side limits of API calls. Limits specified in the block itself are shared by all calls \n" +
+		"to services which have no `service` block of their own.",
+
+	"rate_limit_requests_per_second": "The maximum rate of API calls per second. Calls above the rate are delayed, not failed.",
+
+	"rate_limit_max_in_flight": "The maximum number of API calls executed concurrently.",
+
+	"rate_limit_service": "Limits of calls to a particular service. Each service has its own limits.",
+
+	"rate_limit_service_name": "Name of the service as it appears in API method names, e.g. `compute`, `vpc` or `mdb.postgresql`. \n" +
+		"Names `storage` and `ymq` apply to Object Storage S3 API and Message Queue API calls. The most specific name matching the API method is used.",
+
+	"retry_service_name": "Name of the service as it appears in API method names, e.g. `compute`, `mdb` or `mdb.postgresql`. \n" +
+		"The most specific name matching the API method is used.",
+
 	"storage_endpoint": "Yandex.Cloud storage service endpoint. Default is \n" + DefaultStorageEndpoint,
 
 	"storage_access_key": "Yandex.Cloud storage service access key. \n" +
@@ -52,4 +111,30 @@ var Descriptions = map[string]string{
 	"shared_credentials_file": "Path to shared credentials file.",
 
 	"profile": "Profile to use in the shared credentials file. Default value is `default`.",
+
+	"allow_temporary_access_keys": "Allow creating a temporary service account with a static access key for Object Storage " +
+		"and Message Queue operations, if neither the resource nor the provider has access keys. " +
+		"One key per role is shared by all operations and is deleted together with the service account when the provider exits.",
+
+	"storage_bucket_standalone_configurations": "Refresh policy, CORS, website, logging, lifecycle and server-side encryption " +
+		"configurations of `yandex_storage_bucket` only if they are set in its state, so that they can be managed by standalone resources.",
+
+	"oidc_token": "OIDC JWT issued by a trusted identity provider (e.g. CI system) to exchange for IAM token of `oidc_service_account_id` " +
+		"via workload identity federation.",
+
+	"oidc_token_file": "Path to a file with OIDC JWT to exchange for IAM token of `oidc_service_account_id` via workload identity federation. \n" +
+		"The file is re-read every time IAM token is refreshed.",
+
+	"oidc_service_account_id": "ID of the service account to get IAM token for in exchange for OIDC JWT.",
+
+	"oidc_token_exchange_endpoint": "Token exchange endpoint of workload identity federation. Default is \n" + DefaultTokenExchangeEndpoint,
+
+	"impersonate_service_account_id": "ID of the service account to impersonate. If set, all API calls are made with \n" +
+		"short-lived IAM tokens of this service account, obtained with the credentials configured for the provider.",
+
+	"yc_profile": "Name of the yc CLI profile to take credentials, `folder_id`, `cloud_id`, `organization_id`, `zone` and `endpoint` from. \n" +
+		"Values set explicitly in the provider block or via environment variables take precedence over the profile.",
+
+	"yc_config_file": "Path to the yc CLI config file. Default is `~/.config/yandex-cloud/config.yaml`. \n" +
+		"If set without `yc_profile`, the current yc CLI profile is used.",
 }
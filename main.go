@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
 	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tracing"
 	"github.com/yandex-cloud/terraform-provider-yandex/yandex"
 	yandex_framework "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider"
 )
@@ -32,7 +33,9 @@ func NewMuxProviderServer(ctx context.Context) (func() tfprotov6.ProviderServer,
 		return nil, err
 	}
 
-	return muxServer.ProviderServer, nil
+	return func() tfprotov6.ProviderServer {
+		return tracing.ProviderServer(muxServer.ProviderServer())
+	}, nil
 }
 
 func main() {
@@ -59,6 +62,12 @@ func main() {
 		serveOpts...,
 	)
 
+	// Export spans left in buffer, if tracing has been enabled by provider configuration.
+	_ = tracing.Shutdown(ctx)
+
+	// Delete access keys minted for S3-compatible APIs, when no static keys have been specified.
+	yandex.ReleaseTemporaryStaticAccessKeys()
+
 	if err != nil {
 		return
 	}
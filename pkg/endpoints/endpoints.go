@@ -0,0 +1,200 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/endpoint"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	plaintextScheme = "grpc://"
+	tlsScheme       = "grpcs://"
+
+	listEndpointsMethod = "/yandex.cloud.endpoint.ApiEndpointService/List"
+)
+
+// Override is a custom address of a single service, e.g. "compute".
+type Override struct {
+	// Address in host:port form.
+	Address string
+	// Plaintext disables TLS for the address. If nil, provider-wide plaintext setting is used.
+	Plaintext *bool
+}
+
+// Overrides are keyed by service ID, as listed by ApiEndpointService.
+type Overrides map[string]Override
+
+// Parse parses address of the service. Address may have "grpc://" scheme for plaintext connection
+// or "grpcs://" for TLS one; address without scheme follows provider-wide plaintext setting.
+func Parse(value string) (Override, error) {
+	var o Override
+	switch {
+	case strings.HasPrefix(value, plaintextScheme):
+		plaintext := true
+		o.Plaintext = &plaintext
+		value = strings.TrimPrefix(value, plaintextScheme)
+	case strings.HasPrefix(value, tlsScheme):
+		plaintext := false
+		o.Plaintext = &plaintext
+		value = strings.TrimPrefix(value, tlsScheme)
+	case strings.Contains(value, "://"):
+		return o, fmt.Errorf("unsupported scheme of endpoint %q, only %s and %s are supported", value, plaintextScheme, tlsScheme)
+	}
+
+	if _, _, err := net.SplitHostPort(value); err != nil {
+		return o, fmt.Errorf("invalid endpoint %q, should be in host:port form: %w", value, err)
+	}
+	o.Address = value
+	return o, nil
+}
+
+// Build parses raw service ID to address map, as specified in provider configuration.
+func Build(raw map[string]string) (Overrides, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	result := make(Overrides, len(raw))
+	plaintextByAddress := map[string]*bool{}
+	for _, service := range sortedKeys(raw) {
+		if service == "" {
+			return nil, fmt.Errorf("service ID of the endpoint should not be empty")
+		}
+		o, err := Parse(raw[service])
+		if err != nil {
+			return nil, fmt.Errorf("endpoint of %q service: %w", service, err)
+		}
+		if prev, ok := plaintextByAddress[o.Address]; ok && !samePlaintext(prev, o.Plaintext) {
+			return nil, fmt.Errorf("endpoint %s is specified both with and without TLS", o.Address)
+		}
+		plaintextByAddress[o.Address] = o.Plaintext
+		result[service] = o
+	}
+	return result, nil
+}
+
+func samePlaintext(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Interceptor returns gRPC interceptor that replaces addresses of the overridden services in the list of endpoints
+// SDK discovers at start, so that SDK connects to them instead of the ones provided by the API endpoint.
+func (o Overrides) Interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err != nil || method != listEndpointsMethod {
+			return err
+		}
+		if resp, ok := reply.(*endpoint.ListApiEndpointsResponse); ok {
+			o.apply(resp)
+		}
+		return nil
+	}
+}
+
+func (o Overrides) apply(resp *endpoint.ListApiEndpointsResponse) {
+	seen := map[string]bool{}
+	for _, ep := range resp.GetEndpoints() {
+		if override, ok := o[ep.GetId()]; ok {
+			ep.Address = override.Address
+			seen[ep.GetId()] = true
+		}
+	}
+
+	// Services unknown to the API endpoint are added to the last page, so that each of them is added once.
+	if resp.GetNextPageToken() != "" {
+		return
+	}
+	for _, service := range sortedKeys(o) {
+		if !seen[service] {
+			resp.Endpoints = append(resp.Endpoints, &endpoint.ApiEndpoint{Id: service, Address: o[service].Address})
+		}
+	}
+}
+
+// TransportCredentials returns credentials choosing between TLS and plaintext per address: overridden addresses
+// use their own setting, and the rest use provider-wide one. It replaces credentials SDK builds from its config.
+func (o Overrides) TransportCredentials(tlsConfig *tls.Config, plaintext bool) credentials.TransportCredentials {
+	c := &perAddressCredentials{
+		tls:       credentials.NewTLS(tlsConfig),
+		insecure:  insecure.NewCredentials(),
+		plaintext: plaintext,
+		addresses: map[string]bool{},
+	}
+	for _, override := range o {
+		if override.Plaintext != nil {
+			c.addresses[override.Address] = *override.Plaintext
+		}
+	}
+	return c
+}
+
+type perAddressCredentials struct {
+	tls       credentials.TransportCredentials
+	insecure  credentials.TransportCredentials
+	plaintext bool
+	addresses map[string]bool
+}
+
+func (c *perAddressCredentials) forAuthority(authority string) credentials.TransportCredentials {
+	plaintext, ok := c.addresses[authority]
+	if !ok {
+		plaintext = c.plaintext
+	}
+	if plaintext {
+		return c.insecure
+	}
+	return c.tls
+}
+
+func (c *perAddressCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return c.forAuthority(authority).ClientHandshake(ctx, authority, conn)
+}
+
+func (c *perAddressCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, fmt.Errorf("server handshake is not supported")
+}
+
+func (c *perAddressCredentials) Info() credentials.ProtocolInfo {
+	if c.plaintext {
+		return c.insecure.Info()
+	}
+	return c.tls.Info()
+}
+
+func (c *perAddressCredentials) Clone() credentials.TransportCredentials {
+	addresses := make(map[string]bool, len(c.addresses))
+	for k, v := range c.addresses {
+		addresses[k] = v
+	}
+	return &perAddressCredentials{
+		tls:       c.tls.Clone(),
+		insecure:  c.insecure.Clone(),
+		plaintext: c.plaintext,
+		addresses: addresses,
+	}
+}
+
+func (c *perAddressCredentials) OverrideServerName(serverName string) error {
+	return c.tls.OverrideServerName(serverName) //nolint:staticcheck
+}
@@ -0,0 +1,148 @@
+package endpoints
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/endpoint"
+	"google.golang.org/grpc"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      map[string]string
+		expected Overrides
+		err      string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "schemes",
+			raw: map[string]string{
+				"compute":              "compute.private:443",
+				"iam":                  "grpc://iam.private:8080",
+				"serverless-functions": "grpcs://functions.private:443",
+			},
+			expected: Overrides{
+				"compute":              {Address: "compute.private:443"},
+				"iam":                  {Address: "iam.private:8080", Plaintext: boolPtr(true)},
+				"serverless-functions": {Address: "functions.private:443", Plaintext: boolPtr(false)},
+			},
+		},
+		{
+			name: "same address",
+			raw: map[string]string{
+				"managed-postgresql": "grpcs://mdb.private:443",
+				"managed-mysql":      "grpcs://mdb.private:443",
+			},
+			expected: Overrides{
+				"managed-postgresql": {Address: "mdb.private:443", Plaintext: boolPtr(false)},
+				"managed-mysql":      {Address: "mdb.private:443", Plaintext: boolPtr(false)},
+			},
+		},
+		{
+			name: "same address with different TLS",
+			raw: map[string]string{
+				"managed-postgresql": "grpcs://mdb.private:443",
+				"managed-mysql":      "grpc://mdb.private:443",
+			},
+			err: "both with and without TLS",
+		},
+		{
+			name: "no port",
+			raw:  map[string]string{"compute": "compute.private"},
+			err:  "should be in host:port form",
+		},
+		{
+			name: "unsupported scheme",
+			raw:  map[string]string{"compute": "https://compute.private:443"},
+			err:  "unsupported scheme",
+		},
+		{
+			name: "empty service",
+			raw:  map[string]string{"": "compute.private:443"},
+			err:  "should not be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overrides, err := Build(tt.raw)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, overrides)
+		})
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	overrides, err := Build(map[string]string{
+		"compute":    "grpc://localhost:1000",
+		"serverless": "localhost:1001",
+	})
+	require.NoError(t, err)
+
+	list := func(nextPageToken string) *endpoint.ListApiEndpointsResponse {
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			resp := reply.(*endpoint.ListApiEndpointsResponse)
+			resp.Endpoints = []*endpoint.ApiEndpoint{
+				{Id: "compute", Address: "compute.api.cloud.yandex.net:443"},
+				{Id: "vpc", Address: "vpc.api.cloud.yandex.net:443"},
+			}
+			resp.NextPageToken = nextPageToken
+			return nil
+		}
+		resp := &endpoint.ListApiEndpointsResponse{}
+		require.NoError(t, overrides.Interceptor()(context.Background(), listEndpointsMethod, &endpoint.ListApiEndpointsRequest{}, resp, nil, invoker))
+		return resp
+	}
+
+	addresses := func(resp *endpoint.ListApiEndpointsResponse) map[string]string {
+		result := map[string]string{}
+		for _, ep := range resp.GetEndpoints() {
+			result[ep.GetId()] = ep.GetAddress()
+		}
+		return result
+	}
+
+	assert.Equal(t, map[string]string{
+		"compute": "localhost:1000",
+		"vpc":     "vpc.api.cloud.yandex.net:443",
+	}, addresses(list("next-page")))
+
+	assert.Equal(t, map[string]string{
+		"compute":    "localhost:1000",
+		"vpc":        "vpc.api.cloud.yandex.net:443",
+		"serverless": "localhost:1001",
+	}, addresses(list("")))
+}
+
+func TestTransportCredentials(t *testing.T) {
+	overrides, err := Build(map[string]string{
+		"compute": "grpc://localhost:1000",
+		"iam":     "grpcs://localhost:1001",
+		"vpc":     "localhost:1002",
+	})
+	require.NoError(t, err)
+
+	creds := overrides.TransportCredentials(nil, false).(*perAddressCredentials)
+	assert.Equal(t, "insecure", creds.forAuthority("localhost:1000").Info().SecurityProtocol)
+	assert.Equal(t, "tls", creds.forAuthority("localhost:1001").Info().SecurityProtocol)
+	assert.Equal(t, "tls", creds.forAuthority("localhost:1002").Info().SecurityProtocol)
+	assert.Equal(t, "tls", creds.forAuthority("api.cloud.yandex.net:443").Info().SecurityProtocol)
+
+	creds = overrides.TransportCredentials(nil, true).(*perAddressCredentials)
+	assert.Equal(t, "insecure", creds.forAuthority("localhost:1000").Info().SecurityProtocol)
+	assert.Equal(t, "tls", creds.forAuthority("localhost:1001").Info().SecurityProtocol)
+	assert.Equal(t, "insecure", creds.forAuthority("localhost:1002").Info().SecurityProtocol)
+}
@@ -0,0 +1,296 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/mitchellh/go-homedir"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	AuditProtocolGRPC = "grpc"
+	AuditProtocolS3   = "s3"
+	AuditProtocolSQS  = "sqs"
+)
+
+// readOnlyMethodPrefixes are prefixes of gRPC method and SQS action names that do not change anything.
+var readOnlyMethodPrefixes = []string{"Get", "List", "Read", "Search", "Describe", "Receive"}
+
+// notAuditedServices are called by the provider itself to authenticate, not on behalf of configuration.
+var notAuditedServices = []string{
+	"/yandex.cloud.iam.v1.IamTokenService/",
+	"/yandex.cloud.endpoint.ApiEndpointService/",
+}
+
+// AuditRecord is a single line of the audit log.
+type AuditRecord struct {
+	Time            time.Time       `json:"time"`
+	Protocol        string          `json:"protocol"`
+	Method          string          `json:"method"`
+	Request         json.RawMessage `json:"request,omitempty"`
+	OperationID     string          `json:"operation_id,omitempty"`
+	ClientTraceID   string          `json:"client_trace_id,omitempty"`
+	ClientRequestID string          `json:"client_request_id,omitempty"`
+	ServerRequestID string          `json:"server_request_id,omitempty"`
+	DurationMs      int64           `json:"duration_ms"`
+	Status          string          `json:"status"`
+	Error           string          `json:"error,omitempty"`
+}
+
+// AuditLog writes records of mutating API calls to a file in JSON Lines format.
+type AuditLog struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+var (
+	auditLogsMu sync.Mutex
+	auditLogs   = map[string]*AuditLog{}
+)
+
+// OpenAuditLog opens the audit log file for appending, creating it if needed.
+// Both halves of the provider live in the same process, so they share a single AuditLog per file.
+func OpenAuditLog(filename string) (*AuditLog, error) {
+	path, err := homedir.Expand(filename)
+	if err != nil {
+		return nil, err
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLogsMu.Lock()
+	defer auditLogsMu.Unlock()
+
+	if a, ok := auditLogs[path]; ok {
+		return a, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	a := NewAuditLog(f)
+	auditLogs[path] = a
+	return a, nil
+}
+
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{w: w, now: time.Now}
+}
+
+func (a *AuditLog) write(rec AuditRecord) {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal audit log record of %s: %s", rec.Method, err)
+		return
+	}
+	b = append(b, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(b); err != nil {
+		log.Printf("[ERROR] Failed to write audit log record of %s: %s", rec.Method, err)
+	}
+}
+
+func isReadOnlyMethod(name string) bool {
+	for _, prefix := range readOnlyMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAuditedGRPCMethod(method string) bool {
+	for _, service := range notAuditedServices {
+		if strings.HasPrefix(method, service) {
+			return false
+		}
+	}
+	return !isReadOnlyMethod(method[strings.LastIndexByte(method, '/')+1:])
+}
+
+// UnaryInterceptor returns gRPC interceptor that records mutating calls.
+// It should be placed after requestid interceptor, so that client request IDs are known.
+func (a *AuditLog) UnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !isAuditedGRPCMethod(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		var header metadata.MD
+		opts = append(opts, grpc.Header(&header))
+		start := a.now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		rec := AuditRecord{
+			Time:            start,
+			Protocol:        AuditProtocolGRPC,
+			Method:          method,
+			DurationMs:      a.now().Sub(start).Milliseconds(),
+			ServerRequestID: firstMDValue(header, "x-request-id"),
+		}
+		if md, ok := metadata.FromOutgoingContext(ctx); ok {
+			rec.ClientTraceID = firstMDValue(md, "x-client-trace-id")
+			rec.ClientRequestID = firstMDValue(md, "x-client-request-id")
+		}
+		if m, ok := req.(proto.Message); ok && !IsNil(m) {
+			if b, err := JSONHidingSensitiveValuesMarshaller(m); err == nil {
+				rec.Request = b
+			}
+		}
+		if op, ok := reply.(*operation.Operation); ok && err == nil {
+			rec.OperationID = op.GetId()
+		}
+		st, _ := statusFromError(err)
+		rec.Status = codeString(st.Code())
+		if err != nil {
+			rec.Error = st.Message()
+		}
+
+		a.write(rec)
+		return err
+	}
+}
+
+func firstMDValue(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// Transport wraps HTTP round tripper of S3 or SQS client, so that mutating requests made with it are recorded.
+func (a *AuditLog) Transport(protocol string, base http.RoundTripper) http.RoundTripper {
+	return &auditTransport{audit: a, protocol: protocol, base: base}
+}
+
+type auditTransport struct {
+	audit    *AuditLog
+	protocol string
+	base     http.RoundTripper
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var method string
+	var request json.RawMessage
+	switch t.protocol {
+	case AuditProtocolSQS:
+		form, body, err := readForm(req)
+		if err != nil {
+			return nil, err
+		}
+		req = body
+		method = form.Get("Action")
+		if isReadOnlyMethod(method) {
+			return t.base.RoundTrip(req)
+		}
+		request = sqsRequest(form)
+	default:
+		if req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions {
+			return t.base.RoundTrip(req)
+		}
+		method = s3Method(req)
+	}
+
+	start := t.audit.now()
+	resp, err := t.base.RoundTrip(req)
+
+	rec := AuditRecord{
+		Time:       start,
+		Protocol:   t.protocol,
+		Method:     method,
+		Request:    request,
+		DurationMs: t.audit.now().Sub(start).Milliseconds(),
+	}
+	if err != nil {
+		rec.Status = "ERROR"
+		rec.Error = err.Error()
+	} else {
+		rec.Status = strconv.Itoa(resp.StatusCode)
+		rec.ServerRequestID = resp.Header.Get("X-Amz-Request-Id")
+		if rec.ServerRequestID == "" {
+			rec.ServerRequestID = resp.Header.Get("X-Amzn-Requestid")
+		}
+	}
+
+	t.audit.write(rec)
+	return resp, err
+}
+
+// s3Method describes S3 request as HTTP method, path and names of query parameters, e.g. "PUT /bucket?tagging".
+// Values of query parameters are omitted, because presigned requests carry credentials in them.
+func s3Method(req *http.Request) string {
+	method := req.Method + " " + req.URL.EscapedPath()
+	var keys []string
+	for key := range req.URL.Query() {
+		if !strings.HasPrefix(strings.ToLower(key), "x-amz-") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		method += "?" + strings.Join(keys, "&")
+	}
+	return method
+}
+
+// readForm reads SQS request form, returning request with the body that can be read again.
+func readForm(req *http.Request) (url.Values, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req.URL.Query(), req, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	return form, req, nil
+}
+
+// sqsRequest keeps only parameters identifying the queue: message bodies and attributes are not audited.
+func sqsRequest(form url.Values) json.RawMessage {
+	request := map[string]string{}
+	for _, key := range []string{"QueueName", "QueueUrl"} {
+		if v := form.Get(key); v != "" {
+			request[key] = v
+		}
+	}
+	if len(request) == 0 {
+		return nil
+	}
+	b, _ := json.Marshal(request)
+	return b
+}
@@ -0,0 +1,157 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func readAuditRecords(t *testing.T, buf *bytes.Buffer) []AuditRecord {
+	var records []AuditRecord
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec AuditRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestAuditLogUnaryInterceptor(t *testing.T) {
+	buf := &bytes.Buffer{}
+	audit := NewAuditLog(buf)
+	interceptor := audit.UnaryInterceptor()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"x-client-trace-id", "trace-id",
+		"x-client-request-id", "request-id")
+
+	createInvoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		reply.(*operation.Operation).Id = "operation-id"
+		for _, opt := range opts {
+			if h, ok := opt.(grpc.HeaderCallOption); ok {
+				*h.HeaderAddr = metadata.Pairs("x-request-id", "server-request-id")
+			}
+		}
+		return nil
+	}
+	err := interceptor(ctx, "/yandex.cloud.compute.v1.InstanceService/Create",
+		&compute.CreateInstanceRequest{FolderId: "folder-id", Name: "instance"}, &operation.Operation{}, nil, createInvoker)
+	require.NoError(t, err)
+
+	deleteInvoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.NotFound, "instance not found")
+	}
+	err = interceptor(ctx, "/yandex.cloud.compute.v1.InstanceService/Delete",
+		&compute.DeleteInstanceRequest{InstanceId: "instance-id"}, &operation.Operation{}, nil, deleteInvoker)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	readInvoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+	require.NoError(t, interceptor(ctx, "/yandex.cloud.compute.v1.InstanceService/Get", &compute.GetInstanceRequest{}, &compute.Instance{}, nil, readInvoker))
+	require.NoError(t, interceptor(ctx, "/yandex.cloud.operation.OperationService/Get", &operation.GetOperationRequest{}, &operation.Operation{}, nil, readInvoker))
+	require.NoError(t, interceptor(ctx, "/yandex.cloud.iam.v1.IamTokenService/Create", nil, nil, nil, readInvoker))
+
+	records := readAuditRecords(t, buf)
+	require.Len(t, records, 2)
+
+	create := records[0]
+	assert.Equal(t, AuditProtocolGRPC, create.Protocol)
+	assert.Equal(t, "/yandex.cloud.compute.v1.InstanceService/Create", create.Method)
+	assert.JSONEq(t, `{"folder_id":"folder-id","name":"instance"}`, string(create.Request))
+	assert.Equal(t, "operation-id", create.OperationID)
+	assert.Equal(t, "trace-id", create.ClientTraceID)
+	assert.Equal(t, "request-id", create.ClientRequestID)
+	assert.Equal(t, "server-request-id", create.ServerRequestID)
+	assert.Equal(t, "OK", create.Status)
+	assert.Empty(t, create.Error)
+
+	del := records[1]
+	assert.Equal(t, "/yandex.cloud.compute.v1.InstanceService/Delete", del.Method)
+	assert.Empty(t, del.OperationID)
+	assert.Equal(t, "NOT_FOUND", del.Status)
+	assert.Equal(t, "instance not found", del.Error)
+}
+
+func TestAuditLogTransportS3(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Amz-Request-Id", "s3-request-id")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	buf := &bytes.Buffer{}
+	audit := NewAuditLog(buf)
+	audit.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+	client := &http.Client{Transport: audit.Transport(AuditProtocolS3, http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL + "/bucket/key")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	req, err := http.NewRequest(http.MethodPut, server.URL+"/bucket?tagging&X-Amz-Signature=secret", strings.NewReader("<Tagging/>"))
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	records := readAuditRecords(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, AuditRecord{
+		Time:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Protocol:        AuditProtocolS3,
+		Method:          "PUT /bucket?tagging",
+		ServerRequestID: "s3-request-id",
+		Status:          "200",
+	}, records[0])
+}
+
+func TestAuditLogTransportSQS(t *testing.T) {
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		receivedBody = string(b)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	buf := &bytes.Buffer{}
+	client := &http.Client{Transport: NewAuditLog(buf).Transport(AuditProtocolSQS, http.DefaultTransport)}
+
+	form := url.Values{"Action": {"GetQueueUrl"}, "QueueName": {"queue"}}
+	resp, err := client.PostForm(server.URL, form)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	form = url.Values{"Action": {"CreateQueue"}, "QueueName": {"queue"}, "Attribute.1.Name": {"DelaySeconds"}}
+	resp, err = client.PostForm(server.URL, form)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, form.Encode(), receivedBody)
+
+	records := readAuditRecords(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, AuditProtocolSQS, records[0].Protocol)
+	assert.Equal(t, "CreateQueue", records[0].Method)
+	assert.JSONEq(t, `{"QueueName":"queue"}`, string(records[0].Request))
+	assert.Equal(t, "400", records[0].Status)
+}
@@ -0,0 +1,206 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const servicePrefix = "/yandex.cloud."
+
+// Settings describe limits of API calls. Zero values mean "no limit".
+type Settings struct {
+	RequestsPerSecond float64
+	MaxInFlight       int
+}
+
+// Limiter spaces out API calls evenly to keep their rate below the configured one
+// and caps the number of calls executed concurrently.
+type Limiter struct {
+	interval time.Duration
+	inFlight chan struct{}
+
+	mu   sync.Mutex
+	next time.Time
+	now  func() time.Time
+}
+
+// NewLimiter returns limiter for the settings, or nil if the settings do not limit anything.
+func NewLimiter(s Settings) *Limiter {
+	if s.RequestsPerSecond <= 0 && s.MaxInFlight <= 0 {
+		return nil
+	}
+
+	l := &Limiter{now: time.Now}
+	if s.RequestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / s.RequestsPerSecond)
+	}
+	if s.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, s.MaxInFlight)
+	}
+	return l
+}
+
+// Acquire blocks until the call is allowed to start. The returned function must be called
+// once the call has finished. Nil limiter allows every call immediately.
+func (l *Limiter) Acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if l.inFlight != nil {
+			<-l.inFlight
+		}
+	}
+
+	if err := l.wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+func (l *Limiter) wait(ctx context.Context) error {
+	if l.interval == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := l.now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := start.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Policy holds limiters of all API calls made by the provider.
+type Policy struct {
+	// Default limits calls without a more specific limiter. The limit is shared by all such calls.
+	Default *Limiter
+	// Services limits calls of particular services. Keys are go-sdk service names as they appear
+	// in gRPC method names, e.g. "compute", "vpc" or "mdb.postgresql". The most specific service wins.
+	Services map[string]*Limiter
+}
+
+// RawSettings are limits of a service as configured by user.
+type RawSettings struct {
+	Service string
+	Settings
+}
+
+// Build makes a policy out of user configuration.
+func Build(defaults Settings, services []RawSettings) (*Policy, error) {
+	if defaults.RequestsPerSecond < 0 || defaults.MaxInFlight < 0 {
+		return nil, fmt.Errorf("rate_limit: limits should not be negative")
+	}
+
+	p := &Policy{
+		Default:  NewLimiter(defaults),
+		Services: map[string]*Limiter{},
+	}
+	for _, raw := range services {
+		name := strings.Trim(raw.Service, ".")
+		if name == "" {
+			return nil, fmt.Errorf("rate_limit service: name should not be empty")
+		}
+		if _, ok := p.Services[name]; ok {
+			return nil, fmt.Errorf("rate_limit service %q is specified more than once", name)
+		}
+		if raw.RequestsPerSecond < 0 || raw.MaxInFlight < 0 {
+			return nil, fmt.Errorf("rate_limit service %q: limits should not be negative", name)
+		}
+		// Nil limiter is kept on purpose: it excludes the service from the default limit.
+		p.Services[name] = NewLimiter(raw.Settings)
+	}
+	return p, nil
+}
+
+// LimiterFor returns limiter applied to the given gRPC method.
+func (p *Policy) LimiterFor(method string) *Limiter {
+	if p == nil {
+		return nil
+	}
+
+	best, found := "", false
+	for service := range p.Services {
+		if strings.HasPrefix(method, servicePrefix+service+".") && len(service) >= len(best) {
+			best, found = service, true
+		}
+	}
+	if found {
+		return p.Services[best]
+	}
+	return p.Default
+}
+
+// ServiceLimiter returns limiter applied to calls of the service, e.g. "storage".
+// It is used for clients that do not go through gRPC, like S3 client.
+func (p *Policy) ServiceLimiter(service string) *Limiter {
+	return p.LimiterFor(servicePrefix + service + ".")
+}
+
+// Interceptor returns gRPC interceptor that delays calls according to the policy.
+// It should be placed after the retry interceptor, so that every attempt is limited.
+func (p *Policy) Interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		release, err := p.LimiterFor(method).Acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// Transport wraps HTTP round tripper, so that requests made with it are limited by l.
+func Transport(l *Limiter, base http.RoundTripper) http.RoundTripper {
+	if l == nil {
+		return base
+	}
+	return &transport{limiter: l, base: base}
+}
+
+type transport struct {
+	limiter *Limiter
+	base    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.limiter.Acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestLimiterRequestsPerSecond(t *testing.T) {
+	l := NewLimiter(Settings{RequestsPerSecond: 100})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := l.Acquire(context.Background())
+		require.NoError(t, err)
+		release()
+	}
+
+	// The first call starts immediately, every next one is 10ms after the previous.
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	l := NewLimiter(Settings{MaxInFlight: 2})
+
+	var current, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := l.Acquire(context.Background())
+			require.NoError(t, err)
+			defer release()
+
+			n := atomic.AddInt32(&current, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&current, -1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), peak)
+}
+
+func TestLimiterContextCancelled(t *testing.T) {
+	l := NewLimiter(Settings{MaxInFlight: 1})
+	release, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBuild(t *testing.T) {
+	p, err := Build(Settings{RequestsPerSecond: 10}, []RawSettings{
+		{Service: "compute", Settings: Settings{MaxInFlight: 5}},
+		{Service: "mdb"},
+		{Service: "mdb.postgresql", Settings: Settings{RequestsPerSecond: 1}},
+	})
+	require.NoError(t, err)
+
+	assert.Same(t, p.Default, p.LimiterFor("/yandex.cloud.vpc.v1.NetworkService/Create"))
+	assert.Same(t, p.Services["compute"], p.LimiterFor("/yandex.cloud.compute.v1.InstanceService/Create"))
+	assert.Same(t, p.Services["mdb.postgresql"], p.LimiterFor("/yandex.cloud.mdb.postgresql.v1.ClusterService/Get"))
+	// Service without limits is excluded from the default limit.
+	assert.Nil(t, p.LimiterFor("/yandex.cloud.mdb.mysql.v1.ClusterService/Get"))
+	assert.Same(t, p.Default, p.ServiceLimiter("storage"))
+
+	_, err = Build(Settings{}, []RawSettings{{Service: "compute"}, {Service: "compute"}})
+	assert.ErrorContains(t, err, `rate_limit service "compute" is specified more than once`)
+
+	_, err = Build(Settings{MaxInFlight: -1}, nil)
+	assert.ErrorContains(t, err, "limits should not be negative")
+}
+
+func TestInterceptor(t *testing.T) {
+	p, err := Build(Settings{}, []RawSettings{
+		{Service: "compute", Settings: Settings{MaxInFlight: 1}},
+	})
+	require.NoError(t, err)
+	interceptor := p.Interceptor()
+
+	// Hold the only compute slot, so that the next compute call has to wait.
+	release, err := p.Services["compute"].Acquire(context.Background())
+	require.NoError(t, err)
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = interceptor(ctx, "/yandex.cloud.compute.v1.DiskService/Get", nil, nil, nil, invoker)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	err = interceptor(ctx, "/yandex.cloud.vpc.v1.NetworkService/Get", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+
+	release()
+	err = interceptor(context.Background(), "/yandex.cloud.compute.v1.DiskService/Get", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport(NewLimiter(Settings{RequestsPerSecond: 100}), http.DefaultTransport)}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	assert.Equal(t, http.DefaultTransport, Transport(nil, http.DefaultTransport))
+}
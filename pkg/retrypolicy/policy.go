@@ -0,0 +1,276 @@
+package retrypolicy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yandex-cloud/go-sdk/pkg/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	DefaultBaseDelay = 50 * time.Millisecond
+	DefaultMaxDelay  = 1 * time.Minute
+
+	// operationPollMethod is used by operation waits to poll operation state.
+	operationPollMethod = "/yandex.cloud.operation.OperationService/Get"
+	servicePrefix       = "/yandex.cloud."
+)
+
+var (
+	// DefaultCodes are retried for regular API calls unless configured otherwise.
+	DefaultCodes = []codes.Code{codes.Unavailable}
+	// DefaultOperationPollCodes are retried for operation polls unless configured otherwise.
+	// Polling is idempotent, so it is safe to retry transient server errors as well.
+	DefaultOperationPollCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Internal}
+)
+
+var codesByName = map[string]codes.Code{
+	"CANCELLED":           codes.Canceled,
+	"UNKNOWN":             codes.Unknown,
+	"INVALID_ARGUMENT":    codes.InvalidArgument,
+	"DEADLINE_EXCEEDED":   codes.DeadlineExceeded,
+	"NOT_FOUND":           codes.NotFound,
+	"ALREADY_EXISTS":      codes.AlreadyExists,
+	"PERMISSION_DENIED":   codes.PermissionDenied,
+	"RESOURCE_EXHAUSTED":  codes.ResourceExhausted,
+	"FAILED_PRECONDITION": codes.FailedPrecondition,
+	"ABORTED":             codes.Aborted,
+	"OUT_OF_RANGE":        codes.OutOfRange,
+	"UNIMPLEMENTED":       codes.Unimplemented,
+	"INTERNAL":            codes.Internal,
+	"UNAVAILABLE":         codes.Unavailable,
+	"DATA_LOSS":           codes.DataLoss,
+	"UNAUTHENTICATED":     codes.Unauthenticated,
+}
+
+// CodeNames returns sorted names of gRPC codes accepted by ParseCodes.
+func CodeNames() []string {
+	names := make([]string, 0, len(codesByName))
+	for name := range codesByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseCodes converts gRPC code names like "UNAVAILABLE" into codes.
+func ParseCodes(names []string) ([]codes.Code, error) {
+	result := make([]codes.Code, 0, len(names))
+	for _, name := range names {
+		code, ok := codesByName[strings.ToUpper(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown gRPC code %q, expected one of: %s", name, strings.Join(CodeNames(), ", "))
+		}
+		result = append(result, code)
+	}
+	return result, nil
+}
+
+// ParseDelay parses a delay like "50ms" or "1m". Empty string means zero delay, i.e. "not set".
+func ParseDelay(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("delay %q should not be negative", value)
+	}
+	return d, nil
+}
+
+// Settings describe how a single API call is retried.
+// Zero fields are "not set" and are inherited by Merge.
+type Settings struct {
+	MaxRetries int
+	Codes      []codes.Code
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// Merge returns settings where fields not set in override are taken from s.
+func (s Settings) Merge(override Settings) Settings {
+	if override.MaxRetries != 0 {
+		s.MaxRetries = override.MaxRetries
+	}
+	if len(override.Codes) != 0 {
+		s.Codes = override.Codes
+	}
+	if override.BaseDelay != 0 {
+		s.BaseDelay = override.BaseDelay
+	}
+	if override.MaxDelay != 0 {
+		s.MaxDelay = override.MaxDelay
+	}
+	return s
+}
+
+func (s Settings) interceptor() grpc.UnaryClientInterceptor {
+	return retry.Interceptor(
+		retry.WithMax(s.MaxRetries),
+		retry.WithCodes(s.Codes...),
+		retry.WithAttemptHeader(true),
+		retry.WithBackoff(BackoffExponentialWithJitter(s.BaseDelay, s.MaxDelay)))
+}
+
+// Policy describes retries of all API calls made by the provider.
+type Policy struct {
+	// Default applies to every call without more specific settings.
+	Default Settings
+	// OperationPoll applies to polls made while waiting for long-running operations.
+	OperationPoll Settings
+	// Services applies to calls of particular services. Keys are go-sdk service names
+	// as they appear in gRPC method names, e.g. "compute", "mdb" or "mdb.postgresql".
+	// The most specific service wins.
+	Services map[string]Settings
+}
+
+// NewPolicy returns the policy used when nothing is configured explicitly.
+func NewPolicy(maxRetries int) *Policy {
+	defaults := Settings{
+		MaxRetries: maxRetries,
+		Codes:      DefaultCodes,
+		BaseDelay:  DefaultBaseDelay,
+		MaxDelay:   DefaultMaxDelay,
+	}
+	return &Policy{
+		Default:       defaults,
+		OperationPoll: defaults.Merge(Settings{Codes: DefaultOperationPollCodes}),
+		Services:      map[string]Settings{},
+	}
+}
+
+// Interceptor returns gRPC interceptor that retries calls according to the policy.
+func (p *Policy) Interceptor() grpc.UnaryClientInterceptor {
+	defaultInterceptor := p.Default.interceptor()
+	operationPollInterceptor := p.OperationPoll.interceptor()
+	serviceInterceptors := make(map[string]grpc.UnaryClientInterceptor, len(p.Services))
+	for service, settings := range p.Services {
+		serviceInterceptors[service] = settings.interceptor()
+	}
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		interceptor := defaultInterceptor
+		if method == operationPollMethod {
+			interceptor = operationPollInterceptor
+		} else if service := p.serviceFor(method); service != "" {
+			interceptor = serviceInterceptors[service]
+		}
+		return interceptor(ctx, method, req, reply, cc, invoker, opts...)
+	}
+}
+
+// SettingsFor returns settings that are applied to the given gRPC method.
+func (p *Policy) SettingsFor(method string) Settings {
+	if method == operationPollMethod {
+		return p.OperationPoll
+	}
+	if service := p.serviceFor(method); service != "" {
+		return p.Services[service]
+	}
+	return p.Default
+}
+
+func (p *Policy) serviceFor(method string) string {
+	best := ""
+	for service := range p.Services {
+		if strings.HasPrefix(method, servicePrefix+service+".") && len(service) > len(best) {
+			best = service
+		}
+	}
+	return best
+}
+
+func BackoffExponentialWithJitter(base time.Duration, cap time.Duration) retry.BackoffFunc {
+	return func(attempt int) time.Duration {
+		// First call of BackoffFunc would be with attempt arq equal 0
+		log.Printf("[DEBUG] API call retry attempt %d", attempt+1)
+
+		to := getExponentialTimeout(attempt, base)
+		// Using float types here, because exponential time can be really big, and converting it to time.Duration may
+		// result in undefined behaviour. Its safe conversion, when we have compared it to our 'cap' value.
+		if to > float64(cap) {
+			to = float64(cap)
+		}
+
+		return time.Duration(to * rand.Float64())
+	}
+}
+
+func getExponentialTimeout(attempt int, base time.Duration) float64 {
+	mult := math.Pow(2, float64(attempt))
+	return float64(base) * mult
+}
+
+// RawSettings are retry settings as configured by user, with codes and delays not parsed yet.
+type RawSettings struct {
+	// Service is a name of the service settings apply to. It is only used for per-service settings.
+	Service    string
+	MaxRetries int
+	Codes      []string
+	BaseDelay  string
+	MaxDelay   string
+}
+
+func (r RawSettings) parse() (Settings, error) {
+	var err error
+	s := Settings{MaxRetries: r.MaxRetries}
+	if s.Codes, err = ParseCodes(r.Codes); err != nil {
+		return s, err
+	}
+	if s.BaseDelay, err = ParseDelay(r.BaseDelay); err != nil {
+		return s, fmt.Errorf("invalid base delay: %w", err)
+	}
+	if s.MaxDelay, err = ParseDelay(r.MaxDelay); err != nil {
+		return s, fmt.Errorf("invalid max delay: %w", err)
+	}
+	return s, nil
+}
+
+// Build makes a policy out of user configuration. Settings not configured for a service
+// are inherited from defaults, and defaults are inherited from NewPolicy(maxRetries).
+// Operation polls inherit delays and retry count from defaults, but keep their own codes
+// unless configured explicitly.
+func Build(maxRetries int, defaults RawSettings, operationPoll RawSettings, services []RawSettings) (*Policy, error) {
+	p := NewPolicy(maxRetries)
+
+	d, err := defaults.parse()
+	if err != nil {
+		return nil, fmt.Errorf("retry: %w", err)
+	}
+	p.Default = p.Default.Merge(d)
+
+	op, err := operationPoll.parse()
+	if err != nil {
+		return nil, fmt.Errorf("retry operation_poll: %w", err)
+	}
+	d.Codes = nil
+	p.OperationPoll = p.OperationPoll.Merge(d).Merge(op)
+
+	for _, raw := range services {
+		name := strings.Trim(raw.Service, ".")
+		if name == "" {
+			return nil, fmt.Errorf("retry service: name should not be empty")
+		}
+		if _, ok := p.Services[name]; ok {
+			return nil, fmt.Errorf("retry service %q is specified more than once", name)
+		}
+		s, err := raw.parse()
+		if err != nil {
+			return nil, fmt.Errorf("retry service %q: %w", name, err)
+		}
+		p.Services[name] = p.Default.Merge(s)
+	}
+
+	return p, nil
+}
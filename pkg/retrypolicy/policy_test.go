@@ -0,0 +1,164 @@
+package retrypolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseCodes(t *testing.T) {
+	got, err := ParseCodes([]string{"UNAVAILABLE", "resource_exhausted"})
+	require.NoError(t, err)
+	assert.Equal(t, []codes.Code{codes.Unavailable, codes.ResourceExhausted}, got)
+
+	_, err = ParseCodes([]string{"OK"})
+	assert.ErrorContains(t, err, `unknown gRPC code "OK"`)
+}
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name          string
+		defaults      RawSettings
+		operationPoll RawSettings
+		services      []RawSettings
+		expected      *Policy
+		expectedErr   string
+	}{
+		{
+			name:     "defaults",
+			expected: NewPolicy(5),
+		},
+		{
+			name:          "overrides are inherited",
+			defaults:      RawSettings{Codes: []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"}, BaseDelay: "100ms"},
+			operationPoll: RawSettings{MaxRetries: 10},
+			services: []RawSettings{
+				{Service: "mdb", MaxRetries: 2, MaxDelay: "10s"},
+			},
+			expected: &Policy{
+				Default: Settings{
+					MaxRetries: 5,
+					Codes:      []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+					BaseDelay:  100 * time.Millisecond,
+					MaxDelay:   DefaultMaxDelay,
+				},
+				OperationPoll: Settings{
+					MaxRetries: 10,
+					Codes:      DefaultOperationPollCodes,
+					BaseDelay:  100 * time.Millisecond,
+					MaxDelay:   DefaultMaxDelay,
+				},
+				Services: map[string]Settings{
+					"mdb": {
+						MaxRetries: 2,
+						Codes:      []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+						BaseDelay:  100 * time.Millisecond,
+						MaxDelay:   10 * time.Second,
+					},
+				},
+			},
+		},
+		{
+			name:        "invalid code",
+			defaults:    RawSettings{Codes: []string{"NOPE"}},
+			expectedErr: `retry: unknown gRPC code "NOPE"`,
+		},
+		{
+			name:        "invalid delay",
+			services:    []RawSettings{{Service: "compute", BaseDelay: "soon"}},
+			expectedErr: `retry service "compute": invalid base delay`,
+		},
+		{
+			name:        "duplicate service",
+			services:    []RawSettings{{Service: "compute"}, {Service: "compute"}},
+			expectedErr: `retry service "compute" is specified more than once`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Build(5, tc.defaults, tc.operationPoll, tc.services)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestSettingsFor(t *testing.T) {
+	p, err := Build(5, RawSettings{}, RawSettings{}, []RawSettings{
+		{Service: "mdb", MaxRetries: 1},
+		{Service: "mdb.postgresql", MaxRetries: 2},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, p.SettingsFor("/yandex.cloud.compute.v1.InstanceService/Get").MaxRetries)
+	assert.Equal(t, 1, p.SettingsFor("/yandex.cloud.mdb.mysql.v1.ClusterService/Get").MaxRetries)
+	assert.Equal(t, 2, p.SettingsFor("/yandex.cloud.mdb.postgresql.v1.ClusterService/Get").MaxRetries)
+	assert.Equal(t, 5, p.SettingsFor("/yandex.cloud.mdbx.v1.Service/Get").MaxRetries)
+	assert.Equal(t, DefaultOperationPollCodes, p.SettingsFor(operationPollMethod).Codes)
+}
+
+func TestInterceptor(t *testing.T) {
+	// The first attempt is not counted as a retry, so failing calls are made max_retries+1 times.
+	p, err := Build(3, RawSettings{BaseDelay: "1ms", MaxDelay: "1ms"}, RawSettings{}, []RawSettings{
+		{Service: "compute", Codes: []string{"RESOURCE_EXHAUSTED"}},
+	})
+	require.NoError(t, err)
+	interceptor := p.Interceptor()
+
+	cases := []struct {
+		name          string
+		method        string
+		code          codes.Code
+		expectedCalls int
+	}{
+		{
+			name:          "service code is retried",
+			method:        "/yandex.cloud.compute.v1.InstanceService/Create",
+			code:          codes.ResourceExhausted,
+			expectedCalls: 4,
+		},
+		{
+			name:          "default code is not retried for service with own codes",
+			method:        "/yandex.cloud.compute.v1.InstanceService/Create",
+			code:          codes.Unavailable,
+			expectedCalls: 1,
+		},
+		{
+			name:          "other services use default codes",
+			method:        "/yandex.cloud.vpc.v1.NetworkService/Create",
+			code:          codes.ResourceExhausted,
+			expectedCalls: 1,
+		},
+		{
+			name:          "operation poll retries internal errors",
+			method:        operationPollMethod,
+			code:          codes.Internal,
+			expectedCalls: 4,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				calls++
+				return status.Error(tc.code, "failed")
+			}
+
+			err := interceptor(context.Background(), tc.method, nil, nil, nil, invoker)
+			assert.Equal(t, tc.code, status.Code(err))
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package tokenexchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/mitchellh/go-homedir"
+	iampb "github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+)
+
+const (
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenTypeAccessToken   = "urn:ietf:params:oauth:token-type:access_token"
+	tokenTypeIDToken       = "urn:ietf:params:oauth:token-type:id_token"
+
+	// refreshMargin is how long before the actual expiration the IAM token is considered expired,
+	// so that long applies never send a request with a token that expires in flight.
+	refreshMargin = 5 * time.Minute
+)
+
+// Credentials exchange a third-party OIDC JWT for IAM token of a service account
+// using workload identity federation token exchange endpoint.
+type Credentials struct {
+	// Endpoint is a token exchange endpoint URL.
+	Endpoint string
+	// ServiceAccountID is an ID of the service account the IAM token is issued for.
+	ServiceAccountID string
+	// Token is a subject JWT. It is used when TokenFile is empty.
+	Token string
+	// TokenFile is a path to the file with subject JWT. The file is re-read on every exchange,
+	// so that the CI system is able to rotate short-lived JWT during long applies.
+	TokenFile string
+
+	client *http.Client
+	now    func() time.Time
+}
+
+var _ ycsdk.NonExchangeableCredentials = &Credentials{}
+
+// NewCredentials returns credentials exchanging subject JWT at the endpoint. Requests are sent via transport,
+// which is expected to carry provider proxy and CA settings; default pooled transport is used if it is nil.
+func NewCredentials(endpoint, serviceAccountID, token, tokenFile string, transport http.RoundTripper) (*Credentials, error) {
+	if serviceAccountID == "" {
+		return nil, fmt.Errorf("service account ID is required for OIDC token exchange")
+	}
+	if token == "" && tokenFile == "" {
+		return nil, fmt.Errorf("either OIDC token or OIDC token file is required for OIDC token exchange")
+	}
+	if endpoint == "" {
+		endpoint = common.DefaultTokenExchangeEndpoint
+	}
+
+	client := cleanhttp.DefaultPooledClient()
+	if transport != nil {
+		client = &http.Client{Transport: transport}
+	}
+
+	return &Credentials{
+		Endpoint:         endpoint,
+		ServiceAccountID: serviceAccountID,
+		Token:            token,
+		TokenFile:        tokenFile,
+		client:           client,
+		now:              time.Now,
+	}, nil
+}
+
+func (c *Credentials) YandexCloudAPICredentials() {}
+
+// IAMToken exchanges subject JWT for IAM token. The SDK caches the result until ExpiresAt,
+// which is shifted back by refreshMargin to refresh the token ahead of its expiration.
+func (c *Credentials) IAMToken(ctx context.Context) (*iampb.CreateIamTokenResponse, error) {
+	subjectToken, err := c.subjectToken()
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":           {grantTypeTokenExchange},
+		"requested_token_type": {tokenTypeAccessToken},
+		"audience":             {c.ServiceAccountID},
+		"subject_token":        {subjectToken},
+		"subject_token_type":   {tokenTypeIDToken},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build token exchange request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	issuedAt := c.now()
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token exchange request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token exchange response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+			return nil, fmt.Errorf("token exchange failed: %s: %s", errResp.Error, errResp.ErrorDescription)
+		}
+		return nil, fmt.Errorf("token exchange failed: %s", resp.Status)
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to parse token exchange response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("token exchange response has no access token")
+	}
+
+	lifetime := time.Duration(tokenResp.ExpiresIn) * time.Second
+	if lifetime > refreshMargin {
+		lifetime -= refreshMargin
+	} else {
+		// Token is too short-lived to keep the margin, refresh it halfway through instead.
+		lifetime /= 2
+	}
+
+	return &iampb.CreateIamTokenResponse{
+		IamToken:  tokenResp.AccessToken,
+		ExpiresAt: timestamppb.New(issuedAt.Add(lifetime)),
+	}, nil
+}
+
+func (c *Credentials) subjectToken() (string, error) {
+	if c.TokenFile == "" {
+		return c.Token, nil
+	}
+
+	path, err := homedir.Expand(c.TokenFile)
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read OIDC token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("OIDC token file %q is empty", c.TokenFile)
+	}
+	return token, nil
+}
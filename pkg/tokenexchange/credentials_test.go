@@ -0,0 +1,138 @@
+package tokenexchange
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+)
+
+type fakeTokenEndpoint struct {
+	requests  []map[string]string
+	expiresIn int64
+	status    int
+}
+
+func (f *fakeTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+	req := map[string]string{}
+	for k := range r.PostForm {
+		req[k] = r.PostForm.Get(k)
+	}
+	f.requests = append(f.requests, req)
+
+	w.Header().Set("Content-Type", "application/json")
+	if f.status != 0 && f.status != http.StatusOK {
+		w.WriteHeader(f.status)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"error":             "invalid_grant",
+			"error_description": "subject token is expired",
+		})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token":      "t1.iam-token-" + req["subject_token"],
+		"issued_token_type": tokenTypeAccessToken,
+		"token_type":        "Bearer",
+		"expires_in":        f.expiresIn,
+	})
+}
+
+func TestIAMTokenFromToken(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{expiresIn: 3600}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	creds, err := NewCredentials(server.URL, "sa-id", "jwt", "", nil)
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	creds.now = func() time.Time { return now }
+
+	token, err := creds.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "t1.iam-token-jwt", token.GetIamToken())
+	assert.Equal(t, now.Add(time.Hour-refreshMargin), token.GetExpiresAt().AsTime())
+
+	require.Len(t, endpoint.requests, 1)
+	assert.Equal(t, map[string]string{
+		"grant_type":           grantTypeTokenExchange,
+		"requested_token_type": tokenTypeAccessToken,
+		"audience":             "sa-id",
+		"subject_token":        "jwt",
+		"subject_token_type":   tokenTypeIDToken,
+	}, endpoint.requests[0])
+}
+
+func TestIAMTokenRereadsTokenFile(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{expiresIn: 60}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("first\n"), 0600))
+
+	creds, err := NewCredentials(server.URL, "sa-id", "", tokenFile, nil)
+	require.NoError(t, err)
+
+	token, err := creds.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "t1.iam-token-first", token.GetIamToken())
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("second"), 0600))
+	token, err = creds.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "t1.iam-token-second", token.GetIamToken())
+
+	// Lifetime shorter than refresh margin still gives some time to use the token.
+	assert.True(t, token.GetExpiresAt().AsTime().After(time.Now()))
+}
+
+func TestIAMTokenError(t *testing.T) {
+	server := httptest.NewServer(&fakeTokenEndpoint{status: http.StatusBadRequest})
+	defer server.Close()
+
+	creds, err := NewCredentials(server.URL, "sa-id", "jwt", "", nil)
+	require.NoError(t, err)
+
+	_, err = creds.IAMToken(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid_grant: subject token is expired")
+}
+
+func TestIAMTokenTransport(t *testing.T) {
+	server := httptest.NewTLSServer(&fakeTokenEndpoint{expiresIn: 3600})
+	defer server.Close()
+
+	// Server certificate is trusted only by the transport of the test server client.
+	creds, err := NewCredentials(server.URL, "sa-id", "jwt", "", nil)
+	require.NoError(t, err)
+	_, err = creds.IAMToken(context.Background())
+	require.Error(t, err)
+
+	creds, err = NewCredentials(server.URL, "sa-id", "jwt", "", server.Client().Transport)
+	require.NoError(t, err)
+	token, err := creds.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "t1.iam-token-jwt", token.GetIamToken())
+}
+
+func TestNewCredentialsValidation(t *testing.T) {
+	_, err := NewCredentials("", "", "jwt", "", nil)
+	assert.Error(t, err)
+
+	_, err = NewCredentials("", "sa-id", "", "", nil)
+	assert.Error(t, err)
+
+	creds, err := NewCredentials("", "sa-id", "jwt", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, common.DefaultTokenExchangeEndpoint, creds.Endpoint)
+}
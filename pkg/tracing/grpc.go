@@ -0,0 +1,171 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const operationPollMethod = "/yandex.cloud.operation.OperationService/Get"
+
+// OperationIDKey is an attribute with ID of the operation the span waits for.
+var OperationIDKey = attribute.Key("yandex.operation_id")
+
+// UnaryClientInterceptor returns gRPC interceptor that creates a span per API call.
+// It should be the first one in the chain, so that span covers all retry attempts.
+//
+// Operation polls made by op.Wait are grouped under an "operation wait" span, which lasts
+// from the first poll of the operation till the poll that finds it done.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	ops := &operationSpans{spans: map[string]*operationSpan{}}
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !Enabled() {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		traceID := clientTraceID(ctx)
+		pollReq, isPoll := req.(*operation.GetOperationRequest)
+		if isPoll && method == operationPollMethod {
+			ctx = ops.start(ctx, pollReq.GetOperationId(), traceID)
+		}
+
+		service, name := splitMethod(method)
+		ctx, span := tracer().Start(ctx, strings.TrimPrefix(method, "/"),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.RPCSystemGRPC,
+				semconv.RPCService(service),
+				semconv.RPCMethod(name),
+			))
+		if traceID != "" {
+			span.SetAttributes(ClientTraceIDKey.String(traceID))
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		st := status.Convert(err)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelcodes.Error, st.Message())
+		}
+		span.End()
+
+		if isPoll && method == operationPollMethod {
+			op, _ := reply.(*operation.Operation)
+			ops.finish(pollReq.GetOperationId(), op, err)
+		}
+		return err
+	}
+}
+
+// AttemptInterceptor returns gRPC interceptor that adds an event per attempt to the span of API call.
+// It should be placed right after retry interceptor.
+func AttemptInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if span := trace.SpanFromContext(ctx); span.IsRecording() {
+			span.AddEvent("attempt", trace.WithAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(status.Code(err)))))
+		}
+		return err
+	}
+}
+
+func splitMethod(method string) (service, name string) {
+	method = strings.TrimPrefix(method, "/")
+	if i := strings.LastIndexByte(method, '/'); i >= 0 {
+		return method[:i], method[i+1:]
+	}
+	return "", method
+}
+
+// maxOperationSpans bounds the number of operations waited at the same time, which polls are grouped
+// under an "operation wait" span. Polls of other operations are traced as separate API calls.
+const maxOperationSpans = 1024
+
+type operationSpans struct {
+	mu    sync.Mutex
+	spans map[string]*operationSpan
+}
+
+type operationSpan struct {
+	span trace.Span
+	// stop unregisters ending of the span on cancellation of the wait context.
+	stop func() bool
+}
+
+func (o *operationSpans) start(ctx context.Context, id, traceID string) context.Context {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	s, ok := o.spans[id]
+	if !ok {
+		if len(o.spans) >= maxOperationSpans {
+			return ctx
+		}
+		_, span := tracer().Start(ctx, "operation wait", trace.WithAttributes(OperationIDKey.String(id)))
+		if traceID != "" {
+			span.SetAttributes(ClientTraceIDKey.String(traceID))
+		}
+		s = &operationSpan{span: span}
+		// Operation is not polled anymore once the wait is timed out or cancelled.
+		s.stop = context.AfterFunc(ctx, func() {
+			o.cancel(id, s, context.Cause(ctx))
+		})
+		o.spans[id] = s
+	}
+	return trace.ContextWithSpan(ctx, s.span)
+}
+
+func (o *operationSpans) cancel(id string, s *operationSpan, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.spans[id] != s {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(otelcodes.Error, "operation wait cancelled")
+	s.span.End()
+	delete(o.spans, id)
+}
+
+func (o *operationSpans) finish(id string, op *operation.Operation, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	s, ok := o.spans[id]
+	if !ok {
+		return
+	}
+
+	switch {
+	case status.Code(err) == codes.NotFound:
+		// op.Wait ignores a few NotFound errors, because operation may not be replicated yet.
+		return
+	case err != nil:
+		s.span.RecordError(err)
+		s.span.SetStatus(otelcodes.Error, "operation poll failed")
+	case op == nil || !op.GetDone():
+		return
+	default:
+		s.span.SetAttributes(attribute.String("yandex.operation_description", op.GetDescription()))
+		if opErr := op.GetError(); opErr != nil {
+			s.span.SetStatus(otelcodes.Error, opErr.GetMessage())
+		}
+	}
+
+	s.stop()
+	s.span.End()
+	delete(o.spans, id)
+}
@@ -0,0 +1,107 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// TerraformOperationKey is an attribute with Terraform operation: Create, Read, Update, Delete or Import.
+	TerraformOperationKey = attribute.Key("terraform.operation")
+	// TerraformTypeNameKey is an attribute with type of the resource or data source, e.g. yandex_compute_instance.
+	TerraformTypeNameKey = attribute.Key("terraform.type_name")
+)
+
+// ProviderServer wraps provider server, so that a span is created per Terraform CRUD call.
+// Spans of API calls made with the context of the call are its children.
+func ProviderServer(s tfprotov6.ProviderServer) tfprotov6.ProviderServer {
+	return &providerServer{ProviderServer: s}
+}
+
+type providerServer struct {
+	tfprotov6.ProviderServer
+}
+
+func (s *providerServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	op := "Update"
+	if isNull(req.PriorState) {
+		op = "Create"
+	} else if isNull(req.PlannedState) {
+		op = "Delete"
+	}
+
+	ctx, span := startCRUDSpan(ctx, op, req.TypeName)
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+	var diags []*tfprotov6.Diagnostic
+	if resp != nil {
+		diags = resp.Diagnostics
+	}
+	endCRUDSpan(span, diags, err)
+	return resp, err
+}
+
+func (s *providerServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	ctx, span := startCRUDSpan(ctx, "Read", req.TypeName)
+	resp, err := s.ProviderServer.ReadResource(ctx, req)
+	var diags []*tfprotov6.Diagnostic
+	if resp != nil {
+		diags = resp.Diagnostics
+	}
+	endCRUDSpan(span, diags, err)
+	return resp, err
+}
+
+func (s *providerServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	ctx, span := startCRUDSpan(ctx, "Import", req.TypeName)
+	resp, err := s.ProviderServer.ImportResourceState(ctx, req)
+	var diags []*tfprotov6.Diagnostic
+	if resp != nil {
+		diags = resp.Diagnostics
+	}
+	endCRUDSpan(span, diags, err)
+	return resp, err
+}
+
+func (s *providerServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	ctx, span := startCRUDSpan(ctx, "Read", req.TypeName)
+	resp, err := s.ProviderServer.ReadDataSource(ctx, req)
+	var diags []*tfprotov6.Diagnostic
+	if resp != nil {
+		diags = resp.Diagnostics
+	}
+	endCRUDSpan(span, diags, err)
+	return resp, err
+}
+
+func isNull(v *tfprotov6.DynamicValue) bool {
+	if v == nil {
+		return true
+	}
+	null, err := v.IsNull()
+	return err == nil && null
+}
+
+func startCRUDSpan(ctx context.Context, op, typeName string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, op+" "+typeName, trace.WithAttributes(
+		TerraformOperationKey.String(op),
+		TerraformTypeNameKey.String(typeName),
+	))
+}
+
+func endCRUDSpan(span trace.Span, diags []*tfprotov6.Diagnostic, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	for _, d := range diags {
+		if d != nil && d.Severity == tfprotov6.DiagnosticSeverityError {
+			span.SetStatus(otelcodes.Error, d.Summary)
+			break
+		}
+	}
+	span.End()
+}
@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/version"
+)
+
+const (
+	instrumentationName = "github.com/yandex-cloud/terraform-provider-yandex"
+	serviceName         = "terraform-provider-yandex"
+
+	// tracesExporterEnv is the standard OpenTelemetry variable. Setting it to "otlp" enables tracing
+	// without changes in provider configuration.
+	tracesExporterEnv = "OTEL_TRACES_EXPORTER"
+
+	clientTraceIDHeader = "x-client-trace-id"
+)
+
+// ClientTraceIDKey is an attribute with client-trace-id sent to the API along with the call.
+var ClientTraceIDKey = attribute.Key("yandex.client_trace_id")
+
+// Settings of OTLP trace exporter. Empty values are taken from standard OTEL_EXPORTER_OTLP_* variables.
+type Settings struct {
+	Endpoint string
+	Insecure bool
+}
+
+var (
+	mu       sync.Mutex
+	provider *sdktrace.TracerProvider
+)
+
+// Setup enables tracing if it is configured in provider (s is not nil) or via environment.
+// Both halves of the provider call it, so only the first call takes effect.
+func Setup(s *Settings) error {
+	if s == nil {
+		if os.Getenv(tracesExporterEnv) != "otlp" {
+			return nil
+		}
+		s = &Settings{}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if provider != nil {
+		return nil
+	}
+
+	var opts []otlptracegrpc.Option
+	if s.Endpoint != "" {
+		opts = append(opts, otlptracegrpc.WithEndpoint(s.Endpoint))
+	}
+	if s.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	// Exporter connects lazily, so the context is not kept.
+	exporter, err := otlptracegrpc.New(context.Background(), opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	install(sdktrace.NewBatchSpanProcessor(exporter))
+	return nil
+}
+
+func install(processor sdktrace.SpanProcessor) {
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(version.ProviderVersion),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+}
+
+// Enabled reports whether spans are exported.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return provider != nil
+}
+
+// Shutdown exports remaining spans. It should be called before the provider process exits.
+func Shutdown(ctx context.Context) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if provider == nil {
+		return nil
+	}
+	return provider.Shutdown(ctx)
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName, trace.WithInstrumentationVersion(version.ProviderVersion))
+}
+
+func clientTraceID(ctx context.Context) string {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(clientTraceIDHeader); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
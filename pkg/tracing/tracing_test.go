@@ -0,0 +1,274 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"github.com/yandex-cloud/go-sdk/pkg/requestid"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	mu.Lock()
+	install(recorder)
+	mu.Unlock()
+
+	t.Cleanup(func() {
+		_ = Shutdown(context.Background())
+		mu.Lock()
+		provider = nil
+		mu.Unlock()
+	})
+	return recorder
+}
+
+func spansByName(spans []sdktrace.ReadOnlySpan) map[string][]sdktrace.ReadOnlySpan {
+	result := map[string][]sdktrace.ReadOnlySpan{}
+	for _, s := range spans {
+		result[s.Name()] = append(result[s.Name()], s)
+	}
+	return result
+}
+
+func attributeValue(s sdktrace.ReadOnlySpan, key string) string {
+	for _, kv := range s.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+type fakeCollector struct {
+	collectortrace.UnimplementedTraceServiceServer
+
+	mu    sync.Mutex
+	names []string
+	attrs map[string]string
+}
+
+func (c *fakeCollector) Export(ctx context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rs := range req.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			for _, span := range ss.GetSpans() {
+				c.names = append(c.names, span.GetName())
+				for _, kv := range span.GetAttributes() {
+					c.attrs[kv.GetKey()] = kv.GetValue().GetStringValue()
+				}
+			}
+		}
+	}
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+func TestSetupExportsToCollector(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	collector := &fakeCollector{attrs: map[string]string{}}
+	server := grpc.NewServer()
+	collectortrace.RegisterTraceServiceServer(server, collector)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	require.NoError(t, Setup(&Settings{Endpoint: lis.Addr().String(), Insecure: true}))
+	t.Cleanup(func() {
+		mu.Lock()
+		provider = nil
+		mu.Unlock()
+	})
+	assert.True(t, Enabled())
+
+	ctx := requestid.ContextWithClientTraceID(context.Background(), "client-trace-id")
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+	err = UnaryClientInterceptor()(ctx, "/yandex.cloud.compute.v1.InstanceService/Get", &compute.GetInstanceRequest{}, &compute.Instance{}, nil, invoker)
+	require.NoError(t, err)
+
+	require.NoError(t, Shutdown(context.Background()))
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	assert.Equal(t, []string{"yandex.cloud.compute.v1.InstanceService/Get"}, collector.names)
+	assert.Equal(t, "client-trace-id", collector.attrs[string(ClientTraceIDKey)])
+}
+
+func TestSetupDisabled(t *testing.T) {
+	t.Setenv(tracesExporterEnv, "")
+	require.NoError(t, Setup(nil))
+	assert.False(t, Enabled())
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	recorder := setupRecorder(t)
+	interceptor := UnaryClientInterceptor()
+	ctx := requestid.ContextWithClientTraceID(context.Background(), "client-trace-id")
+
+	createInvoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		reply.(*operation.Operation).Id = "op-id"
+		return nil
+	}
+	require.NoError(t, interceptor(ctx, "/yandex.cloud.compute.v1.InstanceService/Create", &compute.CreateInstanceRequest{}, &operation.Operation{}, nil, createInvoker))
+
+	polls := []func(reply *operation.Operation) error{
+		func(reply *operation.Operation) error { return status.Error(codes.NotFound, "not replicated yet") },
+		func(reply *operation.Operation) error { reply.Id = "op-id"; return nil },
+		func(reply *operation.Operation) error {
+			reply.Id = "op-id"
+			reply.Done = true
+			reply.Description = "Create instance"
+			return nil
+		},
+	}
+	for _, poll := range polls {
+		poll := poll
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return poll(reply.(*operation.Operation))
+		}
+		_ = interceptor(ctx, operationPollMethod, &operation.GetOperationRequest{OperationId: "op-id"}, &operation.Operation{}, nil, invoker)
+	}
+
+	spans := spansByName(recorder.Ended())
+	require.Len(t, spans["yandex.cloud.compute.v1.InstanceService/Create"], 1)
+	create := spans["yandex.cloud.compute.v1.InstanceService/Create"][0]
+	assert.Equal(t, "client-trace-id", attributeValue(create, string(ClientTraceIDKey)))
+	assert.Equal(t, "yandex.cloud.compute.v1.InstanceService", attributeValue(create, "rpc.service"))
+	assert.Equal(t, "Create", attributeValue(create, "rpc.method"))
+
+	require.Len(t, spans["operation wait"], 1)
+	wait := spans["operation wait"][0]
+	assert.Equal(t, "op-id", attributeValue(wait, string(OperationIDKey)))
+	assert.Equal(t, otelcodes.Unset, wait.Status().Code)
+
+	pollSpans := spans["yandex.cloud.operation.OperationService/Get"]
+	require.Len(t, pollSpans, 3)
+	for _, poll := range pollSpans {
+		assert.Equal(t, wait.SpanContext().SpanID(), poll.Parent().SpanID())
+	}
+	assert.Equal(t, otelcodes.Error, pollSpans[0].Status().Code)
+}
+
+func TestUnaryClientInterceptorCancelledWait(t *testing.T) {
+	recorder := setupRecorder(t)
+	ops := &operationSpans{spans: map[string]*operationSpan{}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ops.start(ctx, "op-id", "")
+	ops.finish("op-id", &operation.Operation{Id: "op-id"}, nil)
+	assert.Empty(t, recorder.Ended())
+
+	cancel()
+	require.Eventually(t, func() bool {
+		return len(recorder.Ended()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	wait := recorder.Ended()[0]
+	assert.Equal(t, "operation wait", wait.Name())
+	assert.Equal(t, otelcodes.Error, wait.Status().Code)
+
+	ops.mu.Lock()
+	defer ops.mu.Unlock()
+	assert.Empty(t, ops.spans)
+}
+
+func TestAttemptInterceptor(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	attempts := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attempts++
+		if attempts < 3 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	}
+	// Emulate retry interceptor calling the next one in chain several times.
+	retry := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		var err error
+		for i := 0; i < 3; i++ {
+			if err = AttemptInterceptor()(ctx, method, req, reply, cc, invoker, opts...); err == nil {
+				return nil
+			}
+		}
+		return err
+	}
+
+	err := UnaryClientInterceptor()(context.Background(), "/yandex.cloud.vpc.v1.NetworkService/Create", nil, nil, nil, retry)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Len(t, spans[0].Events(), 3)
+}
+
+type fakeProviderServer struct {
+	tfprotov6.ProviderServer
+}
+
+func (s *fakeProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	resp := &tfprotov6.ApplyResourceChangeResponse{}
+	if req.TypeName == "yandex_failing" {
+		resp.Diagnostics = []*tfprotov6.Diagnostic{{Severity: tfprotov6.DiagnosticSeverityError, Summary: "failed"}}
+	}
+	return resp, nil
+}
+
+func TestProviderServer(t *testing.T) {
+	recorder := setupRecorder(t)
+	server := ProviderServer(&fakeProviderServer{})
+
+	state := &tfprotov6.DynamicValue{JSON: []byte(`{"id":"x"}`)}
+	null := &tfprotov6.DynamicValue{JSON: []byte(`null`)}
+
+	_, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{TypeName: "yandex_vpc_network", PriorState: null, PlannedState: state})
+	require.NoError(t, err)
+	_, err = server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{TypeName: "yandex_vpc_network", PriorState: state, PlannedState: state})
+	require.NoError(t, err)
+	_, err = server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{TypeName: "yandex_failing", PriorState: state, PlannedState: null})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "Create yandex_vpc_network", spans[0].Name())
+	assert.Equal(t, "Update yandex_vpc_network", spans[1].Name())
+	assert.Equal(t, "Delete yandex_failing", spans[2].Name())
+	assert.Equal(t, otelcodes.Error, spans[2].Status().Code)
+}
+
+func TestTransport(t *testing.T) {
+	recorder := setupRecorder(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport("s3", http.DefaultTransport)}
+	resp, err := client.Get(server.URL + "/bucket/key")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "s3 GET", spans[0].Name())
+	assert.Equal(t, "/bucket/key", attributeValue(spans[0], "url.path"))
+	assert.Equal(t, otelcodes.Error, spans[0].Status().Code)
+}
@@ -0,0 +1,296 @@
+package transport
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Settings of connections to the API endpoints, shared by gRPC, S3-compatible and YDB clients.
+// Zero value keeps system CA pool and proxy settings from standard HTTPS_PROXY and NO_PROXY variables.
+type Settings struct {
+	// CAFile is a path to PEM file with certificates trusted in addition to system ones.
+	CAFile string
+	// CAContent is the same as CAFile, but with certificates themselves.
+	CAContent string
+	// HTTPProxy is URL of HTTP proxy all connections go through, except ones to hosts matching NoProxy.
+	HTTPProxy string
+	// NoProxy is a comma-separated list of hosts, domains and CIDRs connected to directly, in NO_PROXY format.
+	NoProxy string
+}
+
+// IsZero reports whether settings keep default behaviour.
+func (s *Settings) IsZero() bool {
+	return s == nil || *s == Settings{}
+}
+
+// Validate checks that settings are consistent and the CA certificates can be loaded.
+func (s *Settings) Validate() error {
+	if s.IsZero() {
+		return nil
+	}
+	if s.CAFile != "" && s.CAContent != "" {
+		return fmt.Errorf("only one of ca_file and ca_content can be specified")
+	}
+	if s.HTTPProxy != "" {
+		if _, err := parseProxyURL(s.HTTPProxy); err != nil {
+			return err
+		}
+	}
+	_, err := s.certPool()
+	return err
+}
+
+// TLSConfig returns TLS config trusting the configured CA certificates.
+func (s *Settings) TLSConfig(insecureSkipVerify bool) (*tls.Config, error) {
+	pool, err := s.certPool()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		RootCAs:            pool,
+		InsecureSkipVerify: insecureSkipVerify,
+	}, nil
+}
+
+// certPool returns system pool with the configured certificates appended, or nil if there are none.
+func (s *Settings) certPool() (*x509.CertPool, error) {
+	pem, err := s.caPEM()
+	if err != nil || pem == nil {
+		return nil, err
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM encoded certificates found in CA bundle")
+	}
+	return pool, nil
+}
+
+func (s *Settings) caPEM() ([]byte, error) {
+	switch {
+	case s.IsZero():
+		return nil, nil
+	case s.CAContent != "":
+		return []byte(s.CAContent), nil
+	case s.CAFile != "":
+		path, err := homedir.Expand(s.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		return pem, nil
+	}
+	return nil, nil
+}
+
+// Proxy returns function choosing proxy for the request URL in the same way as http.Transport.Proxy.
+func (s *Settings) Proxy() func(*url.URL) (*url.URL, error) {
+	if s.IsZero() || s.HTTPProxy == "" {
+		return func(u *url.URL) (*url.URL, error) {
+			return http.ProxyFromEnvironment(&http.Request{URL: u})
+		}
+	}
+	return (&httpproxy.Config{
+		HTTPProxy:  s.HTTPProxy,
+		HTTPSProxy: s.HTTPProxy,
+		NoProxy:    s.NoProxy,
+	}).ProxyFunc()
+}
+
+// HTTPTransport returns a copy of http.DefaultTransport using the configured proxy and CA certificates.
+func (s *Settings) HTTPTransport() (*http.Transport, error) {
+	tlsConfig, err := s.TLSConfig(false)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	proxy := s.Proxy()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+	return transport, nil
+}
+
+// GRPCDialer returns dialer for grpc.WithContextDialer, which tunnels connections through the configured proxy
+// with HTTP CONNECT. It replaces default gRPC dialer, which only knows about proxy from environment.
+func (s *Settings) GRPCDialer() func(ctx context.Context, addr string) (net.Conn, error) {
+	proxy := s.Proxy()
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		proxyURL, err := proxy(&url.URL{Scheme: "https", Host: addr})
+		if err != nil {
+			return nil, err
+		}
+
+		dialer := &net.Dialer{}
+		if proxyURL == nil {
+			return dialer.DialContext(ctx, "tcp", addr)
+		}
+
+		conn, err := dialer.DialContext(ctx, "tcp", canonicalAddr(proxyURL))
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to proxy %s: %w", proxyURL.Host, err)
+		}
+		tunnel, err := connectHandshake(ctx, conn, addr, proxyURL)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return tunnel, nil
+	}
+}
+
+func connectHandshake(ctx context.Context, conn net.Conn, addr string, proxyURL *url.URL) (net.Conn, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer func() { _ = conn.SetDeadline(time.Time{}) }()
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if user := proxyURL.User; user != nil {
+		password, _ := user.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, fmt.Errorf("failed to write CONNECT request to proxy: %w", err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CONNECT response from proxy: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy refused to connect to %s: %s", addr, resp.Status)
+	}
+	if br.Buffered() > 0 {
+		// Target has already sent something through the tunnel, and it is in the buffer.
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+func canonicalAddr(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	if u.Scheme == "https" {
+		return net.JoinHostPort(u.Hostname(), "443")
+	}
+	return net.JoinHostPort(u.Hostname(), "80")
+}
+
+func parseProxyURL(proxy string) (*url.URL, error) {
+	// Same as httpproxy: URL without scheme means HTTP proxy.
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid http_proxy %q", proxy)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported http_proxy scheme %q, only http and https are supported", u.Scheme)
+	}
+	return u, nil
+}
+
+// SetYDBEnvironment passes settings to YDB SDK. YDB connections are opened by resources of terraform-provider-ydb,
+// which takes no connection options, so the settings are passed via environment variables read by YDB SDK and gRPC.
+// Proxy variables are read by the process only once, so it must be called before any connection is made.
+//
+// The variables are changed for the whole provider process: YDB_SSL_ROOT_CERTIFICATES_FILE is set only if CA
+// certificates are configured, HTTPS_PROXY and NO_PROXY only if proxy is configured. Other clients of the provider
+// get the settings explicitly and are not affected by the variables.
+func (s *Settings) SetYDBEnvironment() error {
+	if s.IsZero() {
+		return nil
+	}
+
+	caFile := s.CAFile
+	if s.CAContent != "" {
+		var err error
+		if caFile, err = writeYDBCAFile(s.CAContent); err != nil {
+			return err
+		}
+	}
+	if caFile != "" {
+		if err := os.Setenv("YDB_SSL_ROOT_CERTIFICATES_FILE", caFile); err != nil {
+			return err
+		}
+	}
+
+	if s.HTTPProxy != "" {
+		for key, value := range map[string]string{"HTTPS_PROXY": s.HTTPProxy, "NO_PROXY": s.NoProxy} {
+			if err := os.Setenv(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeYDBCAFile writes CA certificates to a file named after their hash, so that every provider process
+// with the same certificates reuses the same file instead of leaving a new one on every run.
+func writeYDBCAFile(content string) (string, error) {
+	hash := sha256.Sum256([]byte(content))
+	name := filepath.Join(os.TempDir(), fmt.Sprintf("terraform-provider-yandex-ca-%x.pem", hash[:8]))
+	if existing, err := os.ReadFile(name); err == nil && string(existing) == content {
+		return name, nil
+	}
+
+	// Written to a temporary file first, so that concurrent processes never read a partially written one
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return "", fmt.Errorf("failed to write CA certificates for YDB: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return "", fmt.Errorf("failed to write CA certificates for YDB: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write CA certificates for YDB: %w", err)
+	}
+	if err := os.Rename(f.Name(), name); err != nil {
+		return "", fmt.Errorf("failed to write CA certificates for YDB: %w", err)
+	}
+	return name, nil
+}
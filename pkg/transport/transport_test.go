@@ -0,0 +1,205 @@
+package transport
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serverCAContent(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+// proxiedHost is used as a target host in tests, because loopback addresses are never proxied.
+// Test proxy connects to the same port on loopback instead, and certificates of httptest servers are valid for it.
+const proxiedHost = "example.com"
+
+func proxiedAddr(addr net.Addr) string {
+	_, port, _ := net.SplitHostPort(addr.String())
+	return net.JoinHostPort(proxiedHost, port)
+}
+
+// newConnectProxy starts HTTP proxy that only supports CONNECT and counts tunnels it has opened.
+func newConnectProxy(t *testing.T) (*httptest.Server, *int32) {
+	var tunnels int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			http.Error(w, "only CONNECT is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		_, port, _ := net.SplitHostPort(r.Host)
+		upstream, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", port))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		atomic.AddInt32(&tunnels, 1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			upstream.Close()
+			return
+		}
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go func() {
+			_, _ = io.Copy(upstream, conn)
+			upstream.Close()
+		}()
+		go func() {
+			_, _ = io.Copy(conn, upstream)
+			conn.Close()
+		}()
+	}))
+	t.Cleanup(proxy.Close)
+	return proxy, &tunnels
+}
+
+func TestSettingsValidate(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte(serverCAContent(server)), 0600))
+
+	tests := []struct {
+		name     string
+		settings *Settings
+		err      string
+	}{
+		{name: "nil", settings: nil},
+		{name: "ca file", settings: &Settings{CAFile: caFile}},
+		{name: "ca content", settings: &Settings{CAContent: serverCAContent(server)}},
+		{name: "proxy without scheme", settings: &Settings{HTTPProxy: "proxy.local:3128"}},
+		{name: "both ca", settings: &Settings{CAFile: caFile, CAContent: serverCAContent(server)}, err: "only one of"},
+		{name: "missing ca file", settings: &Settings{CAFile: filepath.Join(t.TempDir(), "missing.pem")}, err: "failed to read CA file"},
+		{name: "not a pem", settings: &Settings{CAContent: "garbage"}, err: "no PEM encoded certificates"},
+		{name: "socks proxy", settings: &Settings{HTTPProxy: "socks5://proxy.local:1080"}, err: "unsupported http_proxy scheme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSettingsProxy(t *testing.T) {
+	s := &Settings{HTTPProxy: "http://proxy.local:3128", NoProxy: "internal.local,10.0.0.0/8"}
+	proxy := s.Proxy()
+
+	tests := []struct {
+		target string
+		proxy  string
+	}{
+		{target: "https://api.cloud.yandex.net:443", proxy: "http://proxy.local:3128"},
+		{target: "https://storage.yandexcloud.net", proxy: "http://proxy.local:3128"},
+		{target: "https://db.internal.local:2135", proxy: ""},
+		{target: "https://10.1.2.3:443", proxy: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			u, err := url.Parse(tt.target)
+			require.NoError(t, err)
+			got, err := proxy(u)
+			require.NoError(t, err)
+			if tt.proxy == "" {
+				assert.Nil(t, got)
+			} else {
+				require.NotNil(t, got)
+				assert.Equal(t, tt.proxy, got.String())
+			}
+		})
+	}
+}
+
+func TestWriteYDBCAFile(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	name, err := writeYDBCAFile("first")
+	require.NoError(t, err)
+	again, err := writeYDBCAFile("first")
+	require.NoError(t, err)
+	assert.Equal(t, name, again, "file with the same certificates must be reused")
+
+	other, err := writeYDBCAFile("second")
+	require.NoError(t, err)
+	assert.NotEqual(t, name, other)
+
+	content, err := os.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(content))
+
+	files, err := os.ReadDir(os.TempDir())
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestHTTPTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	proxy, tunnels := newConnectProxy(t)
+
+	transport, err := (&Settings{}).HTTPTransport()
+	require.NoError(t, err)
+	_, err = (&http.Client{Transport: transport}).Get(server.URL)
+	require.Error(t, err, "server certificate should not be trusted without CA")
+
+	transport, err = (&Settings{CAContent: serverCAContent(server), HTTPProxy: proxy.URL}).HTTPTransport()
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: transport}).Get("https://" + proxiedAddr(server.Listener.Addr()))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(tunnels))
+}
+
+func TestGRPCDialer(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte("hello"))
+			conn.Close()
+		}
+	}()
+	proxy, tunnels := newConnectProxy(t)
+
+	read := func(s *Settings, addr string) string {
+		conn, err := s.GRPCDialer()(context.Background(), addr)
+		require.NoError(t, err)
+		defer conn.Close()
+		b, err := io.ReadAll(conn)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	assert.Equal(t, "hello", read(&Settings{HTTPProxy: proxy.URL}, proxiedAddr(lis.Addr())))
+	assert.Equal(t, int32(1), atomic.LoadInt32(tunnels))
+
+	assert.Equal(t, "hello", read(&Settings{HTTPProxy: proxy.URL}, lis.Addr().String()))
+	assert.Equal(t, int32(1), atomic.LoadInt32(tunnels), "loopback should be connected to directly")
+
+	_, err = (&Settings{HTTPProxy: proxy.URL}).GRPCDialer()(context.Background(), proxiedHost+":1")
+	assert.ErrorContains(t, err, "proxy refused to connect")
+}
@@ -0,0 +1,206 @@
+package ycprofile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	iampb "github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"github.com/yandex-cloud/go-sdk/iamkey"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultConfigFile is the location where yc CLI keeps its profiles.
+	DefaultConfigFile = "~/.config/yandex-cloud/config.yaml"
+
+	// cliTokenLifetime is how long a token issued by `yc iam create-token` is cached.
+	// Real IAM tokens live for 12 hours, so refreshing hourly is safely conservative.
+	cliTokenLifetime = time.Hour
+)
+
+// Profile holds the subset of yc CLI profile settings the provider can make use of.
+type Profile struct {
+	Name string
+
+	Token                  string
+	ServiceAccountKey      *iamkey.Key
+	FederationID           string
+	InstanceServiceAccount bool
+	Endpoint               string
+	CloudID                string
+	FolderID               string
+	OrganizationID         string
+	Zone                   string
+
+	cliExecutable string
+}
+
+type rawConfig struct {
+	Current  string                `yaml:"current"`
+	Profiles map[string]rawProfile `yaml:"profiles"`
+}
+
+type rawProfile struct {
+	Token                  string                 `yaml:"token"`
+	ServiceAccountKey      map[string]interface{} `yaml:"service-account-key"`
+	FederationID           string                 `yaml:"federation-id"`
+	InstanceServiceAccount bool                   `yaml:"instance-service-account"`
+	Endpoint               string                 `yaml:"endpoint"`
+	CloudID                string                 `yaml:"cloud-id"`
+	FolderID               string                 `yaml:"folder-id"`
+	OrganizationID         string                 `yaml:"organization-id"`
+	ComputeDefaultZone     string                 `yaml:"compute-default-zone"`
+}
+
+// Load reads yc CLI config from filename and returns the profile called name.
+// Empty filename means DefaultConfigFile, empty name means the profile marked as current.
+func Load(filename, name string) (*Profile, error) {
+	if filename == "" {
+		filename = DefaultConfigFile
+	}
+	path, err := homedir.Expand(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read yc CLI config: %w", err)
+	}
+
+	return parse(data, name)
+}
+
+func parse(data []byte, name string) (*Profile, error) {
+	var raw rawConfig
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse yc CLI config: %w", err)
+	}
+
+	if name == "" {
+		name = raw.Current
+	}
+	if name == "" {
+		return nil, fmt.Errorf("yc CLI profile is not specified and there is no current profile in yc CLI config")
+	}
+
+	rp, ok := raw.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("not found yc CLI profile `%v`", name)
+	}
+
+	profile := &Profile{
+		Name:                   name,
+		Token:                  rp.Token,
+		FederationID:           rp.FederationID,
+		InstanceServiceAccount: rp.InstanceServiceAccount,
+		Endpoint:               rp.Endpoint,
+		CloudID:                rp.CloudID,
+		FolderID:               rp.FolderID,
+		OrganizationID:         rp.OrganizationID,
+		Zone:                   rp.ComputeDefaultZone,
+		cliExecutable:          "yc",
+	}
+
+	if rp.ServiceAccountKey != nil {
+		content, err := json.Marshal(rp.ServiceAccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read service account key of yc CLI profile `%v`: %w", name, err)
+		}
+		key, err := iamkey.ReadFromJSONBytes(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read service account key of yc CLI profile `%v`: %w", name, err)
+		}
+		profile.ServiceAccountKey = key
+	}
+
+	return profile, nil
+}
+
+// HasCredentials reports whether the profile defines any way to authenticate.
+func (p *Profile) HasCredentials() bool {
+	return p.ServiceAccountKey != nil || p.Token != "" || p.FederationID != "" || p.InstanceServiceAccount
+}
+
+// Credentials builds SDK credentials out of the profile. It returns nil if the profile has no credentials.
+//
+// Federated profiles cannot be authenticated without a browser, so for them
+// tokens are obtained from the yc CLI itself, which owns the federation session.
+func (p *Profile) Credentials() (ycsdk.Credentials, error) {
+	switch {
+	case p.ServiceAccountKey != nil:
+		return ycsdk.ServiceAccountKey(p.ServiceAccountKey)
+	case p.Token != "":
+		if IsIAMToken(p.Token) {
+			return ycsdk.NewIAMTokenCredentials(p.Token), nil
+		}
+		return ycsdk.OAuthToken(p.Token), nil
+	case p.FederationID != "":
+		return &cliCredentials{executable: p.cliExecutable, profile: p.Name}, nil
+	case p.InstanceServiceAccount:
+		return ycsdk.InstanceServiceAccount(), nil
+	}
+	return nil, nil
+}
+
+// IsIAMToken tells IAM tokens apart from OAuth tokens.
+func IsIAMToken(token string) bool {
+	return strings.HasPrefix(token, "t1.") && strings.Count(token, ".") == 2
+}
+
+// FirstNonEmpty returns the first non empty value; it is used to implement
+// "explicit value, then yc CLI profile, then default" precedence.
+func FirstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// cliCredentials obtains IAM tokens by running `yc iam create-token` for the profile.
+type cliCredentials struct {
+	executable string
+	profile    string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+var _ ycsdk.NonExchangeableCredentials = &cliCredentials{}
+
+func (c *cliCredentials) YandexCloudAPICredentials() {}
+
+func (c *cliCredentials) IAMToken(ctx context.Context) (*iampb.CreateIamTokenResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token == "" || time.Now().After(c.expiresAt) {
+		out, err := exec.CommandContext(ctx, c.executable, "iam", "create-token", "--profile", c.profile).Output()
+		if err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				return nil, fmt.Errorf("failed to get IAM token from yc CLI profile `%v`: %s", c.profile, strings.TrimSpace(string(exitErr.Stderr)))
+			}
+			return nil, fmt.Errorf("failed to get IAM token from yc CLI profile `%v`: %w", c.profile, err)
+		}
+		c.token = strings.TrimSpace(string(out))
+		c.expiresAt = time.Now().Add(cliTokenLifetime)
+	}
+
+	return &iampb.CreateIamTokenResponse{
+		IamToken:  c.token,
+		ExpiresAt: timestamppb.New(c.expiresAt),
+	}, nil
+}
@@ -0,0 +1,149 @@
+package ycprofile
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+)
+
+const testConfig = `
+current: user
+profiles:
+  user:
+    token: AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA
+    cloud-id: cloud-id
+    folder-id: folder-id
+    compute-default-zone: ru-central1-a
+  iam:
+    token: t1.some.token
+    organization-id: organization-id
+    endpoint: api.example.net:443
+  federated:
+    federation-id: federation-id
+  sa:
+    service-account-key:
+      id: key-id
+      service_account_id: sa-id
+      key_algorithm: RSA_2048
+      private_key: |
+        PLEASE DO NOT REMOVE THIS LINE! Yandex.Cloud SA Key ID <key-id>
+  metadata:
+    instance-service-account: true
+  empty: {}
+`
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name            string
+		profile         string
+		expected        *Profile
+		expectedError   string
+		hasCredentials  bool
+		credentialsType interface{}
+	}{
+		{
+			name:    "current profile",
+			profile: "",
+			expected: &Profile{
+				Name:     "user",
+				Token:    "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+				CloudID:  "cloud-id",
+				FolderID: "folder-id",
+				Zone:     "ru-central1-a",
+			},
+			hasCredentials: true,
+		},
+		{
+			name:    "profile with IAM token",
+			profile: "iam",
+			expected: &Profile{
+				Name:           "iam",
+				Token:          "t1.some.token",
+				OrganizationID: "organization-id",
+				Endpoint:       "api.example.net:443",
+			},
+			hasCredentials:  true,
+			credentialsType: &ycsdk.IAMTokenCredentials{},
+		},
+		{
+			name:            "federated profile",
+			profile:         "federated",
+			expected:        &Profile{Name: "federated", FederationID: "federation-id"},
+			hasCredentials:  true,
+			credentialsType: &cliCredentials{},
+		},
+		{
+			name:           "instance service account profile",
+			profile:        "metadata",
+			expected:       &Profile{Name: "metadata", InstanceServiceAccount: true},
+			hasCredentials: true,
+		},
+		{
+			name:     "profile without credentials",
+			profile:  "empty",
+			expected: &Profile{Name: "empty"},
+		},
+		{
+			name:          "unknown profile",
+			profile:       "unknown",
+			expectedError: "not found yc CLI profile `unknown`",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := parse([]byte(testConfig), tc.profile)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.True(t, strings.Contains(err.Error(), tc.expectedError), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+
+			tc.expected.cliExecutable = "yc"
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.hasCredentials, result.HasCredentials())
+
+			credentials, err := result.Credentials()
+			require.NoError(t, err)
+			if !tc.hasCredentials {
+				assert.Nil(t, credentials)
+			}
+			if tc.credentialsType != nil {
+				assert.IsType(t, tc.credentialsType, credentials)
+			}
+		})
+	}
+}
+
+func TestParseServiceAccountKey(t *testing.T) {
+	result, err := parse([]byte(testConfig), "sa")
+	require.NoError(t, err)
+	require.NotNil(t, result.ServiceAccountKey)
+	assert.Equal(t, "key-id", result.ServiceAccountKey.GetId())
+	assert.Equal(t, "sa-id", result.ServiceAccountKey.GetServiceAccountId())
+}
+
+func TestParseWithoutCurrentProfile(t *testing.T) {
+	_, err := parse([]byte("profiles:\n  default: {}\n"), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "there is no current profile")
+}
+
+func TestCLICredentials(t *testing.T) {
+	credentials := &cliCredentials{executable: "echo", profile: "federated"}
+
+	token, err := credentials.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "iam create-token --profile federated", token.GetIamToken())
+	assert.NoError(t, token.GetExpiresAt().CheckValid())
+}
+
+func TestFirstNonEmpty(t *testing.T) {
+	assert.Equal(t, "b", FirstNonEmpty("", "b", "c"))
+	assert.Equal(t, "", FirstNonEmpty("", ""))
+}
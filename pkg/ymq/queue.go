@@ -0,0 +1,37 @@
+package ymq
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// QueueURL is a parsed URL of Message Queue queue.
+type QueueURL struct {
+	// Account is the ID of the folder the queue belongs to.
+	Account string
+	// ID is the internal ID of the queue.
+	ID   string
+	Name string
+}
+
+// ParseQueueURL splits queue URL to its components.
+func ParseQueueURL(queueURL string) (QueueURL, error) {
+	// Example: https://message-queue.api.cloud.yandex.net/b1g8ad42m6he1ooql78r/dj6000000000qq9v07ol/yet-another-queue
+	u, err := url.Parse(queueURL)
+	if err != nil {
+		return QueueURL{}, err
+	}
+	segments := strings.Split(u.Path, "/")
+	if len(segments) != 4 {
+		return QueueURL{}, fmt.Errorf("Message queue url was not parsed correctly")
+	}
+
+	return QueueURL{Account: segments[1], ID: segments[2], Name: segments[3]}, nil
+}
+
+// ARN returns ARN (YRN) of the queue in the region.
+func (q QueueURL) ARN(region string) string {
+	// Example: yrn:yc:ymq:ru-central1:b1g8ad42m6he1ooql78r:yet-another-queue
+	return fmt.Sprintf("yrn:yc:ymq:%s:%s:%s", region, q.Account, q.Name)
+}
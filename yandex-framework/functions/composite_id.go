@@ -0,0 +1,111 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/resourceid"
+)
+
+type buildCompositeIDFunction struct{}
+
+// NewBuildCompositeIDFunction returns function building "<parent_id>:<name>" ID of resources nested into
+// another one, e.g. databases and users of managed database clusters.
+func NewBuildCompositeIDFunction() function.Function {
+	return &buildCompositeIDFunction{}
+}
+
+func (f *buildCompositeIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_composite_id"
+}
+
+func (f *buildCompositeIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build composite ID of a nested resource",
+		Description: "Builds ID of a resource nested into another one, e.g. a database of a managed database cluster, in <parent_id>:<name> form.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "parent_id",
+				Description: "ID of the parent resource, e.g. a cluster ID.",
+			},
+			function.StringParameter{
+				Name:        "name",
+				Description: "Name of the nested resource.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *buildCompositeIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var parentID, name string
+	resp.Error = req.Arguments.Get(ctx, &parentID, &name)
+	if resp.Error != nil {
+		return
+	}
+
+	if parentID == "" {
+		resp.Error = function.NewArgumentFuncError(0, "parent_id should not be empty")
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, resourceid.Construct(parentID, name))
+}
+
+type parseCompositeIDFunction struct{}
+
+var compositeIDAttributeTypes = map[string]attr.Type{
+	"parent_id": types.StringType,
+	"name":      types.StringType,
+}
+
+// NewParseCompositeIDFunction returns function splitting "<parent_id>:<name>" ID of a nested resource.
+func NewParseCompositeIDFunction() function.Function {
+	return &parseCompositeIDFunction{}
+}
+
+func (f *parseCompositeIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_composite_id"
+}
+
+func (f *parseCompositeIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse composite ID of a nested resource",
+		Description: "Splits ID of a nested resource in <parent_id>:<name> form into an object with parent_id and name attributes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "Composite ID to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: compositeIDAttributeTypes,
+		},
+	}
+}
+
+func (f *parseCompositeIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	parentID, name, err := resourceid.Deconstruct(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(compositeIDAttributeTypes, map[string]attr.Value{
+		"parent_id": types.StringValue(parentID),
+		"name":      types.StringValue(name),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, result)
+}
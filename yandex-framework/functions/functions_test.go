@@ -0,0 +1,143 @@
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func run(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	ctx := context.Background()
+	definitionResp := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, definitionResp)
+	require.False(t, definitionResp.Diagnostics.HasError(), "definition: %v", definitionResp.Diagnostics)
+	validateResp := &function.DefinitionValidateResponse{}
+	definitionResp.Definition.ValidateImplementation(ctx, function.DefinitionValidateRequest{FuncName: "test"}, validateResp)
+	require.False(t, validateResp.Diagnostics.HasError(), "definition: %v", validateResp.Diagnostics)
+
+	result, funcErr := definitionResp.Definition.Return.NewResultData(ctx)
+	require.Nil(t, funcErr)
+	resp := &function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func stringList(values ...string) attr.Value {
+	elementTypes := make([]attr.Type, 0, len(values))
+	elements := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elementTypes = append(elementTypes, types.StringType)
+		elements = append(elements, types.StringValue(v))
+	}
+	return types.TupleValueMust(elementTypes, elements)
+}
+
+func TestCompositeID(t *testing.T) {
+	result, err := run(t, NewBuildCompositeIDFunction(), types.StringValue("c9qmlqf4q8ctl6j1q0fc"), types.StringValue("db1"))
+	require.Nil(t, err)
+	assert.Equal(t, types.StringValue("c9qmlqf4q8ctl6j1q0fc:db1"), result)
+
+	_, err = run(t, NewBuildCompositeIDFunction(), types.StringValue(""), types.StringValue("db1"))
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "parent_id should not be empty")
+
+	result, err = run(t, NewParseCompositeIDFunction(), types.StringValue("c9qmlqf4q8ctl6j1q0fc:user:1"))
+	require.Nil(t, err)
+	assert.Equal(t, types.ObjectValueMust(compositeIDAttributeTypes, map[string]attr.Value{
+		"parent_id": types.StringValue("c9qmlqf4q8ctl6j1q0fc"),
+		"name":      types.StringValue("user:1"),
+	}), result)
+
+	_, err = run(t, NewParseCompositeIDFunction(), types.StringValue("c9qmlqf4q8ctl6j1q0fc"))
+	require.NotNil(t, err)
+}
+
+func TestImportID(t *testing.T) {
+	tests := []struct {
+		name     string
+		parts    []string
+		expected string
+		err      string
+	}{
+		{
+			name:     "two parts",
+			parts:    []string{"b1g8ad42m6he1ooql78r", "my-network"},
+			expected: "b1g8ad42m6he1ooql78r/my-network",
+		},
+		{
+			name:     "three parts",
+			parts:    []string{"dns1234567890abcdefg", "www", "A"},
+			expected: "dns1234567890abcdefg/www/A",
+		},
+		{
+			name:  "single part",
+			parts: []string{"b1g8ad42m6he1ooql78r"},
+			err:   "at least two parts",
+		},
+		{
+			name:  "separator in part",
+			parts: []string{"b1g8ad42m6he1ooql78r", "a/b"},
+			err:   "should not contain",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := run(t, NewBuildImportIDFunction(), stringList(tt.parts...))
+			if tt.err != "" {
+				require.NotNil(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, types.StringValue(tt.expected), result)
+
+			result, err = run(t, NewParseImportIDFunction(), types.StringValue(tt.expected))
+			require.Nil(t, err)
+			assert.Equal(t, types.ListValueMust(types.StringType, stringList(tt.parts...).(types.Tuple).Elements()), result)
+		})
+	}
+
+	_, err := run(t, NewParseImportIDFunction(), types.StringValue("b1g8ad42m6he1ooql78r//A"))
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "empty part")
+}
+
+func TestQueueURLToARN(t *testing.T) {
+	const queueURL = "https://message-queue.api.cloud.yandex.net/b1g8ad42m6he1ooql78r/dj6000000000qq9v07ol/yet-another-queue"
+
+	result, err := run(t, NewQueueURLToARNFunction(), types.StringValue(queueURL), types.StringNull())
+	require.Nil(t, err)
+	assert.Equal(t, types.StringValue("yrn:yc:ymq:ru-central1:b1g8ad42m6he1ooql78r:yet-another-queue"), result)
+
+	result, err = run(t, NewQueueURLToARNFunction(), types.StringValue(queueURL), types.StringValue("kz1"))
+	require.Nil(t, err)
+	assert.Equal(t, types.StringValue("yrn:yc:ymq:kz1:b1g8ad42m6he1ooql78r:yet-another-queue"), result)
+
+	_, err = run(t, NewQueueURLToARNFunction(), types.StringValue("https://message-queue.api.cloud.yandex.net/queue"), types.StringNull())
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "was not parsed correctly")
+}
+
+func TestIsValidID(t *testing.T) {
+	for id, expected := range map[string]bool{
+		"b1g8ad42m6he1ooql78r":  true,
+		"fd8vmcue7aajpmeo39kk":  true,
+		"B1G8AD42M6HE1OOQL78R":  false,
+		"1bg8ad42m6he1ooql78r":  false,
+		"b1g8ad42m6he1ooql78":   false,
+		"b1g8ad42m6he1ooql78rr": false,
+		"b1g8ad42-6he1ooql78r":  false,
+		"":                      false,
+	} {
+		result, err := run(t, NewIsValidIDFunction(), types.StringValue(id))
+		require.Nil(t, err)
+		assert.Equal(t, types.BoolValue(expected), result, id)
+	}
+}
@@ -0,0 +1,104 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const importIDSeparator = "/"
+
+type buildImportIDFunction struct{}
+
+// NewBuildImportIDFunction returns function joining parts of the import ID with "/",
+// e.g. "<zone_id>/<name>/<type>" of DNS record set.
+func NewBuildImportIDFunction() function.Function {
+	return &buildImportIDFunction{}
+}
+
+func (f *buildImportIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_import_id"
+}
+
+func (f *buildImportIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build import ID from its parts",
+		Description: "Joins parts of the import ID with /, e.g. <folder_id>/<name> or <zone_id>/<name>/<type>.",
+		VariadicParameter: function.StringParameter{
+			Name:        "parts",
+			Description: "Parts of the import ID, at least two. Parts should not contain /.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *buildImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var parts []string
+	resp.Error = req.Arguments.Get(ctx, &parts)
+	if resp.Error != nil {
+		return
+	}
+
+	if len(parts) < 2 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("at least two parts are expected, got %d", len(parts)))
+		return
+	}
+	for i, part := range parts {
+		if part == "" || strings.Contains(part, importIDSeparator) {
+			resp.Error = function.NewArgumentFuncError(int64(i), fmt.Sprintf("part %d should be non-empty and should not contain %q, got %q", i, importIDSeparator, part))
+			return
+		}
+	}
+	resp.Error = resp.Result.Set(ctx, strings.Join(parts, importIDSeparator))
+}
+
+type parseImportIDFunction struct{}
+
+// NewParseImportIDFunction returns function splitting import ID into its "/" separated parts.
+func NewParseImportIDFunction() function.Function {
+	return &parseImportIDFunction{}
+}
+
+func (f *parseImportIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_import_id"
+}
+
+func (f *parseImportIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse import ID into its parts",
+		Description: "Splits import ID like <folder_id>/<name> into a list of its parts.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "Import ID to parse.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *parseImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	parts := strings.Split(id, importIDSeparator)
+	if len(parts) < 2 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("import ID %q should have at least two parts separated by %q", id, importIDSeparator))
+		return
+	}
+	for _, part := range parts {
+		if part == "" {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("import ID %q has empty part", id))
+			return
+		}
+	}
+	resp.Error = resp.Result.Set(ctx, parts)
+}
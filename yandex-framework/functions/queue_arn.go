@@ -0,0 +1,61 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ymq"
+)
+
+const defaultYMQRegion = "ru-central1"
+
+type queueURLToARNFunction struct{}
+
+// NewQueueURLToARNFunction returns function converting URL of Message Queue queue to its ARN.
+func NewQueueURLToARNFunction() function.Function {
+	return &queueURLToARNFunction{}
+}
+
+func (f *queueURLToARNFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "queue_url_to_arn"
+}
+
+func (f *queueURLToARNFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert Message Queue URL to ARN",
+		Description: "Converts URL of Yandex Message Queue queue to its ARN, e.g. for use in policies or triggers.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "queue_url",
+				Description: "URL of the queue.",
+			},
+			function.StringParameter{
+				Name:           "region",
+				Description:    "Region of the queue. If null, ru-central1 is used.",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *queueURLToARNFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var queueURL string
+	var region *string
+	resp.Error = req.Arguments.Get(ctx, &queueURL, &region)
+	if resp.Error != nil {
+		return
+	}
+
+	queue, err := ymq.ParseQueueURL(queueURL)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	queueRegion := defaultYMQRegion
+	if region != nil && *region != "" {
+		queueRegion = *region
+	}
+	resp.Error = resp.Result.Set(ctx, queue.ARN(queueRegion))
+}
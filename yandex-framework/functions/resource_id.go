@@ -0,0 +1,47 @@
+package functions
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Resource IDs are 20 characters long: 3 characters of the service prefix followed by 17 lowercase
+// letters or digits, e.g. "b1g8ad42m6he1ooql78r" or "fd8vmcue7aajpmeo39kk".
+var resourceIDRegexp = regexp.MustCompile(`^[a-z][a-z0-9]{19}$`)
+
+type isValidIDFunction struct{}
+
+// NewIsValidIDFunction returns function checking whether the string has format of Yandex Cloud resource ID.
+func NewIsValidIDFunction() function.Function {
+	return &isValidIDFunction{}
+}
+
+func (f *isValidIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_id"
+}
+
+func (f *isValidIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check format of resource ID",
+		Description: "Returns true if the string has format of Yandex Cloud resource ID: 20 lowercase letters or digits, starting with a letter. Existence of the resource is not checked.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "String to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *isValidIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, resourceIDRegexp.MatchString(id))
+}
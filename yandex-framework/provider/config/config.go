@@ -2,14 +2,12 @@ package config
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
-	"math"
-	"math/rand"
 	"net"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -18,41 +16,62 @@ import (
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/mitchellh/go-homedir"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
 	ycsdk "github.com/yandex-cloud/go-sdk"
 	"github.com/yandex-cloud/go-sdk/iamkey"
 	"github.com/yandex-cloud/go-sdk/pkg/requestid"
-	"github.com/yandex-cloud/go-sdk/pkg/retry"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 
 	"github.com/yandex-cloud/terraform-provider-yandex/pkg/config"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/endpoints"
 	"github.com/yandex-cloud/terraform-provider-yandex/pkg/logging"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ratelimit"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/retrypolicy"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tokenexchange"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tracing"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/transport"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ycprofile"
 )
 
 const (
 	DefaultTimeout = 1 * time.Minute
 )
 
-const (
-	defaultExponentialBackoffBase = 50 * time.Millisecond
-	defaultExponentialBackoffCap  = 1 * time.Minute
-)
-
 type State struct {
-	Endpoint                       types.String `tfsdk:"endpoint"`
-	FolderID                       types.String `tfsdk:"folder_id"`
-	CloudID                        types.String `tfsdk:"cloud_id"`
-	OrganizationID                 types.String `tfsdk:"organization_id"`
-	Zone                           types.String `tfsdk:"zone"`
-	Token                          types.String `tfsdk:"token"`
-	ServiceAccountKeyFileOrContent types.String `tfsdk:"service_account_key_file"`
-	Plaintext                      types.Bool   `tfsdk:"plaintext"`
-	Insecure                       types.Bool   `tfsdk:"insecure"`
-	MaxRetries                     types.Int64  `tfsdk:"max_retries"`
-	StorageEndpoint                types.String `tfsdk:"storage_endpoint"`
-	YMQEndpoint                    types.String `tfsdk:"ymq_endpoint"`
-	Region                         types.String `tfsdk:"region_id"`
+	Endpoint                       types.String     `tfsdk:"endpoint"`
+	Endpoints                      types.Map        `tfsdk:"endpoints"`
+	FolderID                       types.String     `tfsdk:"folder_id"`
+	CloudID                        types.String     `tfsdk:"cloud_id"`
+	OrganizationID                 types.String     `tfsdk:"organization_id"`
+	Zone                           types.String     `tfsdk:"zone"`
+	Token                          types.String     `tfsdk:"token"`
+	ServiceAccountKeyFileOrContent types.String     `tfsdk:"service_account_key_file"`
+	Plaintext                      types.Bool       `tfsdk:"plaintext"`
+	Insecure                       types.Bool       `tfsdk:"insecure"`
+	CAFile                         types.String     `tfsdk:"ca_file"`
+	CAContent                      types.String     `tfsdk:"ca_content"`
+	HTTPProxy                      types.String     `tfsdk:"http_proxy"`
+	NoProxy                        types.String     `tfsdk:"no_proxy"`
+	MaxRetries                     types.Int64      `tfsdk:"max_retries"`
+	Retry                          []RetryState     `tfsdk:"retry"`
+	RateLimit                      []RateLimitState `tfsdk:"rate_limit"`
+	Tracing                        []TracingState   `tfsdk:"tracing"`
+	StorageEndpoint                types.String     `tfsdk:"storage_endpoint"`
+	YMQEndpoint                    types.String     `tfsdk:"ymq_endpoint"`
+	Region                         types.String     `tfsdk:"region_id"`
+
+	// OIDC JWT (or a file with it) exchanged for IAM token of OIDCServiceAccountID
+	// via workload identity federation.
+	OIDCToken                 types.String `tfsdk:"oidc_token"`
+	OIDCTokenFile             types.String `tfsdk:"oidc_token_file"`
+	OIDCServiceAccountID      types.String `tfsdk:"oidc_service_account_id"`
+	OIDCTokenExchangeEndpoint types.String `tfsdk:"oidc_token_exchange_endpoint"`
+
+	// ImpersonateServiceAccountID is an ID of the service account every API call is made on behalf of.
+	ImpersonateServiceAccountID types.String `tfsdk:"impersonate_service_account_id"`
+
+	AuditLogFile types.String `tfsdk:"audit_log_file"`
 
 	// These storage access keys are optional and only used when
 	// storage data/resource doesn't have own access keys explicitly specified.
@@ -64,37 +83,108 @@ type State struct {
 	YMQAccessKey types.String `tfsdk:"ymq_access_key"`
 	YMQSecretKey types.String `tfsdk:"ymq_secret_key"`
 
+	AllowTemporaryAccessKeys types.Bool `tfsdk:"allow_temporary_access_keys"`
+
+	StorageBucketStandaloneConfigurations types.Bool `tfsdk:"storage_bucket_standalone_configurations"`
+
 	SharedCredentialsFile types.String `tfsdk:"shared_credentials_file"`
 	Profile               types.String `tfsdk:"profile"`
+
+	YCProfile    types.String `tfsdk:"yc_profile"`
+	YCConfigFile types.String `tfsdk:"yc_config_file"`
 	//
 	//sharedCredentials *SharedCredentials
 	//defaultS3Client   *s3.S3
 }
 
+type RetryState struct {
+	Codes         types.List                  `tfsdk:"codes"`
+	BaseDelay     types.String                `tfsdk:"base_delay"`
+	MaxDelay      types.String                `tfsdk:"max_delay"`
+	OperationPoll []RetrySettingsState        `tfsdk:"operation_poll"`
+	Service       []RetryServiceSettingsState `tfsdk:"service"`
+}
+
+type RetrySettingsState struct {
+	MaxRetries types.Int64  `tfsdk:"max_retries"`
+	Codes      types.List   `tfsdk:"codes"`
+	BaseDelay  types.String `tfsdk:"base_delay"`
+	MaxDelay   types.String `tfsdk:"max_delay"`
+}
+
+type RetryServiceSettingsState struct {
+	Name       types.String `tfsdk:"name"`
+	MaxRetries types.Int64  `tfsdk:"max_retries"`
+	Codes      types.List   `tfsdk:"codes"`
+	BaseDelay  types.String `tfsdk:"base_delay"`
+	MaxDelay   types.String `tfsdk:"max_delay"`
+}
+
+type TracingState struct {
+	OTLPEndpoint types.String `tfsdk:"otlp_endpoint"`
+	Insecure     types.Bool   `tfsdk:"insecure"`
+}
+
+type RateLimitState struct {
+	RequestsPerSecond types.Float64           `tfsdk:"requests_per_second"`
+	MaxInFlight       types.Int64             `tfsdk:"max_in_flight"`
+	Service           []RateLimitServiceState `tfsdk:"service"`
+}
+
+type RateLimitServiceState struct {
+	Name              types.String  `tfsdk:"name"`
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	MaxInFlight       types.Int64   `tfsdk:"max_in_flight"`
+}
+
 // TODO: remove yandex.Config when it is not used
 type Config struct {
 	ProviderState State
 
 	UserAgent types.String
 	SDK       *ycsdk.SDK
+
+	// CLIProfile is yc CLI profile used as a fallback source of credentials and defaults.
+	CLIProfile *ycprofile.Profile
+
+	// RetryPolicy is built from the retry block; default policy is used if it is nil.
+	RetryPolicy *retrypolicy.Policy
+	// RateLimitPolicy is built from the rate_limit block; API calls are not limited if it is nil.
+	RateLimitPolicy *ratelimit.Policy
+	// Tracing is built from the tracing block; tracing is enabled by environment only if it is nil.
+	Tracing *tracing.Settings
+	// Endpoints are custom addresses of individual services, built from the endpoints map.
+	Endpoints endpoints.Overrides
 }
 
 // Client configures and returns a fully initialized Yandex.Cloud SDK
 func (c *Config) InitAndValidate(ctx context.Context, terraformVersion string, sweeper bool) error {
 	ctx = requestid.ContextWithClientTraceID(ctx, uuid.New().String())
 
+	if err := tracing.Setup(c.Tracing); err != nil {
+		return err
+	}
+
+	transportSettings := c.transportSettings()
+	if err := transportSettings.Validate(); err != nil {
+		return err
+	}
+
 	credentials, err := c.Credentials(ctx)
 	if err != nil {
 		return err
 	}
 
+	tlsConfig, err := transportSettings.TLSConfig(c.ProviderState.Insecure.ValueBool())
+	if err != nil {
+		return err
+	}
+
 	yandexSDKConfig := &ycsdk.Config{
 		Credentials: credentials,
 		Endpoint:    c.ProviderState.Endpoint.ValueString(),
 		Plaintext:   c.ProviderState.Plaintext.ValueBool(),
-		TLSConfig: &tls.Config{
-			InsecureSkipVerify: c.ProviderState.Insecure.ValueBool(),
-		},
+		TLSConfig:   tlsConfig,
 	}
 
 	c.UserAgent = types.StringValue(config.BuildUserAgent(terraformVersion, sweeper))
@@ -103,15 +193,32 @@ func (c *Config) InitAndValidate(ctx context.Context, terraformVersion string, s
 
 	requestIDInterceptor := requestid.Interceptor()
 
-	retryInterceptor := retry.Interceptor(
-		retry.WithMax(int(c.ProviderState.MaxRetries.ValueInt64())),
-		retry.WithCodes(codes.Unavailable),
-		retry.WithAttemptHeader(true),
-		retry.WithBackoff(backoffExponentialWithJitter(defaultExponentialBackoffBase, defaultExponentialBackoffCap)))
+	retryPolicy := c.RetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = retrypolicy.NewPolicy(int(c.ProviderState.MaxRetries.ValueInt64()))
+	}
+	retryInterceptor := retryPolicy.Interceptor()
+
+	var interceptors []grpc.UnaryClientInterceptor
+	if tracing.Enabled() {
+		interceptors = append(interceptors, tracing.UnaryClientInterceptor(), retryInterceptor, tracing.AttemptInterceptor())
+	} else {
+		interceptors = append(interceptors, retryInterceptor)
+	}
+	if c.RateLimitPolicy != nil {
+		interceptors = append(interceptors, c.RateLimitPolicy.Interceptor())
+	}
+	interceptors = append(interceptors, requestIDInterceptor)
+	if len(c.Endpoints) > 0 {
+		interceptors = append(interceptors, c.Endpoints.Interceptor())
+	}
 
-	var interceptors = []grpc.UnaryClientInterceptor{
-		retryInterceptor,
-		requestIDInterceptor,
+	if auditLogFile := c.ProviderState.AuditLogFile.ValueString(); auditLogFile != "" {
+		auditLog, err := logging.OpenAuditLog(auditLogFile)
+		if err != nil {
+			return err
+		}
+		interceptors = append(interceptors, auditLog.UnaryInterceptor())
 	}
 
 	// Support deep API logging in case user has requested it.
@@ -124,14 +231,47 @@ func (c *Config) InitAndValidate(ctx context.Context, terraformVersion string, s
 	// Now we will have new request id for every retry attempt.
 	interceptorChain := grpc_middleware.ChainUnaryClient(interceptors...)
 
-	c.SDK, err = ycsdk.Build(ctx, *yandexSDKConfig,
+	callOptions := []grpc.CallOption{grpc.Header(&headerMD)}
+	if saID := c.ProviderState.ImpersonateServiceAccountID.ValueString(); saID != "" {
+		// SDK exchanges base credentials for IAM token of the service account and caches it until expiration.
+		callOptions = append(callOptions, ycsdk.WithAuthAsServiceAccount(saID))
+	}
+
+	dialOptions := []grpc.DialOption{
 		grpc.WithUserAgent(c.UserAgent.ValueString()),
-		grpc.WithDefaultCallOptions(grpc.Header(&headerMD)),
-		grpc.WithUnaryInterceptor(interceptorChain))
+		grpc.WithDefaultCallOptions(callOptions...),
+		grpc.WithUnaryInterceptor(interceptorChain),
+	}
+	if transportSettings.HTTPProxy != "" {
+		dialOptions = append(dialOptions, grpc.WithContextDialer(transportSettings.GRPCDialer()))
+	}
+	if len(c.Endpoints) > 0 {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(c.Endpoints.TransportCredentials(tlsConfig, c.ProviderState.Plaintext.ValueBool())))
+	}
+
+	c.SDK, err = ycsdk.Build(ctx, *yandexSDKConfig, dialOptions...)
 
 	return err
 }
 
+func (c *Config) transportSettings() *transport.Settings {
+	return &transport.Settings{
+		CAFile:    c.ProviderState.CAFile.ValueString(),
+		CAContent: c.ProviderState.CAContent.ValueString(),
+		HTTPProxy: c.ProviderState.HTTPProxy.ValueString(),
+		NoProxy:   c.ProviderState.NoProxy.ValueString(),
+	}
+}
+
+// CreateIAMToken returns IAM token for clients that are not built on top of SDK connection.
+// The token belongs to the impersonated service account if there is one.
+func (c *Config) CreateIAMToken(ctx context.Context) (*iam.CreateIamTokenResponse, error) {
+	if saID := c.ProviderState.ImpersonateServiceAccountID.ValueString(); saID != "" {
+		return c.SDK.CreateIAMTokenForServiceAccount(ctx, saID)
+	}
+	return c.SDK.CreateIAMToken(ctx)
+}
+
 func (c *Config) Credentials(ctx context.Context) (ycsdk.Credentials, error) {
 	if c.ProviderState.ServiceAccountKeyFileOrContent.ValueString() != "" {
 		contents, _, err := pathOrContents(c.ProviderState.ServiceAccountKeyFileOrContent.ValueString())
@@ -157,11 +297,38 @@ func (c *Config) Credentials(ctx context.Context) (ycsdk.Credentials, error) {
 		return ycsdk.OAuthToken(c.ProviderState.Token.ValueString()), nil
 	}
 
+	if c.ProviderState.OIDCToken.ValueString() != "" || c.ProviderState.OIDCTokenFile.ValueString() != "" {
+		// Token exchange is a plain HTTP call, so it needs proxy and CA settings applied explicitly.
+		var httpTransport http.RoundTripper
+		if transportSettings := c.transportSettings(); !transportSettings.IsZero() {
+			t, err := transportSettings.HTTPTransport()
+			if err != nil {
+				return nil, err
+			}
+			httpTransport = t
+		}
+		oidcCredentials, err := tokenexchange.NewCredentials(
+			c.ProviderState.OIDCTokenExchangeEndpoint.ValueString(),
+			c.ProviderState.OIDCServiceAccountID.ValueString(),
+			c.ProviderState.OIDCToken.ValueString(),
+			c.ProviderState.OIDCTokenFile.ValueString(),
+			httpTransport,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return oidcCredentials, nil
+	}
+
+	if c.CLIProfile != nil && c.CLIProfile.HasCredentials() {
+		return c.CLIProfile.Credentials()
+	}
+
 	if sa := ycsdk.InstanceServiceAccount(); checkServiceAccountAvailable(ctx, sa) {
 		return sa, nil
 	}
 
-	return nil, fmt.Errorf("one of 'token' or 'service_account_key_file' should be specified;" +
+	return nil, fmt.Errorf("one of 'token', 'service_account_key_file', 'oidc_token', 'oidc_token_file' or 'yc_profile' should be specified;" +
 		" if you are inside compute instance, you can attach service account to it in order to " +
 		"authenticate via instance service account")
 }
@@ -175,27 +342,6 @@ func iamKeyFromJSONContent(content string) (*iamkey.Key, error) {
 	return key, nil
 }
 
-func backoffExponentialWithJitter(base time.Duration, cap time.Duration) retry.BackoffFunc {
-	return func(attempt int) time.Duration {
-		// First call of BackoffFunc would be with attempt arq equal 0
-		log.Printf("[DEBUG] API call retry attempt %d", attempt+1)
-
-		to := getExponentialTimeout(attempt, base)
-		// Using float types here, because exponential time can be really big, and converting it to time.Duration may
-		// result in undefined behaviour. Its safe conversion, when we have compared it to our 'cap' value.
-		if to > float64(cap) {
-			to = float64(cap)
-		}
-
-		return time.Duration(to * rand.Float64())
-	}
-}
-
-func getExponentialTimeout(attempt int, base time.Duration) float64 {
-	mult := math.Pow(2, float64(attempt))
-	return float64(base) * mult
-}
-
 func checkServiceAccountAvailable(ctx context.Context, sa ycsdk.NonExchangeableCredentials) bool {
 	dialer := net.Dialer{Timeout: 50 * time.Millisecond}
 	conn, err := dialer.Dial("tcp", net.JoinHostPort(ycsdk.InstanceMetadataAddr, "80"))
@@ -7,8 +7,12 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -16,6 +20,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/endpoints"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ratelimit"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/retrypolicy"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tracing"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ycprofile"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/functions"
 	provider_config "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider/config"
 	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/services/billing"
 	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/services/compute/disk"
@@ -29,6 +39,9 @@ import (
 	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/services/compute/snapshotschedule"
 	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/services/datasphere/community"
 	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/services/datasphere/project"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/services/iam/staticaccesskey"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/services/iam/token"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/services/lockbox/secretversion"
 	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/services/mdb/mongodb/database"
 	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/services/mdb/mongodb/user"
 	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/services/mdb/opensearch"
@@ -76,6 +89,20 @@ func (p *Provider) ConfigValidators(ctx context.Context) []provider.ConfigValida
 		providervalidator.Conflicting(
 			path.MatchRoot("token"),
 			path.MatchRoot("service_account_key_file"),
+			path.MatchRoot("oidc_token"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("token"),
+			path.MatchRoot("service_account_key_file"),
+			path.MatchRoot("oidc_token_file"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("oidc_token"),
+			path.MatchRoot("oidc_token_file"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("ca_file"),
+			path.MatchRoot("ca_content"),
 		),
 	}
 }
@@ -123,6 +150,27 @@ func (p *Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp
 					saKeyValidator{},
 				},
 			},
+			"oidc_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: common.Descriptions["oidc_token"],
+			},
+			"oidc_token_file": schema.StringAttribute{
+				Optional:    true,
+				Description: common.Descriptions["oidc_token_file"],
+			},
+			"oidc_service_account_id": schema.StringAttribute{
+				Optional:    true,
+				Description: common.Descriptions["oidc_service_account_id"],
+			},
+			"oidc_token_exchange_endpoint": schema.StringAttribute{
+				Optional:    true,
+				Description: common.Descriptions["oidc_token_exchange_endpoint"],
+			},
+			"impersonate_service_account_id": schema.StringAttribute{
+				Optional:    true,
+				Description: common.Descriptions["impersonate_service_account_id"],
+			},
 			"storage_endpoint": schema.StringAttribute{
 				Optional:    true,
 				Description: common.Descriptions["storage_endpoint"],
@@ -144,6 +192,27 @@ func (p *Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp
 				Optional:    true,
 				Description: common.Descriptions["plaintext"],
 			},
+			"endpoints": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: common.Descriptions["endpoints"],
+			},
+			"ca_file": schema.StringAttribute{
+				Optional:    true,
+				Description: common.Descriptions["ca_file"],
+			},
+			"ca_content": schema.StringAttribute{
+				Optional:    true,
+				Description: common.Descriptions["ca_content"],
+			},
+			"http_proxy": schema.StringAttribute{
+				Optional:    true,
+				Description: common.Descriptions["http_proxy"],
+			},
+			"no_proxy": schema.StringAttribute{
+				Optional:    true,
+				Description: common.Descriptions["no_proxy"],
+			},
 			"max_retries": schema.Int64Attribute{
 				Optional:    true,
 				Description: common.Descriptions["max_retries"],
@@ -161,6 +230,14 @@ func (p *Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp
 				Sensitive:   true,
 				Description: common.Descriptions["ymq_secret_key"],
 			},
+			"allow_temporary_access_keys": schema.BoolAttribute{
+				Optional:    true,
+				Description: common.Descriptions["allow_temporary_access_keys"],
+			},
+			"storage_bucket_standalone_configurations": schema.BoolAttribute{
+				Optional:    true,
+				Description: common.Descriptions["storage_bucket_standalone_configurations"],
+			},
 			"shared_credentials_file": schema.StringAttribute{
 				Optional:    true,
 				Description: common.Descriptions["shared_credentials_file"],
@@ -169,10 +246,149 @@ func (p *Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp
 				Optional:    true,
 				Description: common.Descriptions["profile"],
 			},
+			"audit_log_file": schema.StringAttribute{
+				Optional:    true,
+				Description: common.Descriptions["audit_log_file"],
+			},
+			"yc_profile": schema.StringAttribute{
+				Optional:    true,
+				Description: common.Descriptions["yc_profile"],
+			},
+			"yc_config_file": schema.StringAttribute{
+				Optional:    true,
+				Description: common.Descriptions["yc_config_file"],
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"tracing": schema.ListNestedBlock{
+				Description: common.Descriptions["tracing"],
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"otlp_endpoint": schema.StringAttribute{
+							Optional:    true,
+							Description: common.Descriptions["tracing_otlp_endpoint"],
+						},
+						"insecure": schema.BoolAttribute{
+							Optional:    true,
+							Description: common.Descriptions["tracing_insecure"],
+						},
+					},
+				},
+			},
+			"rate_limit": schema.ListNestedBlock{
+				Description: common.Descriptions["rate_limit"],
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"requests_per_second": rateLimitRequestsPerSecondAttribute(),
+						"max_in_flight":       rateLimitMaxInFlightAttribute(),
+					},
+					Blocks: map[string]schema.Block{
+						"service": schema.ListNestedBlock{
+							Description: common.Descriptions["rate_limit_service"],
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Required:    true,
+										Description: common.Descriptions["rate_limit_service_name"],
+									},
+									"requests_per_second": rateLimitRequestsPerSecondAttribute(),
+									"max_in_flight":       rateLimitMaxInFlightAttribute(),
+								},
+							},
+						},
+					},
+				},
+			},
+			"retry": schema.ListNestedBlock{
+				Description: common.Descriptions["retry"],
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"codes":      retryCodesAttribute(),
+						"base_delay": retryDelayAttribute("retry_base_delay"),
+						"max_delay":  retryDelayAttribute("retry_max_delay"),
+					},
+					Blocks: map[string]schema.Block{
+						"operation_poll": schema.ListNestedBlock{
+							Description: common.Descriptions["retry_operation_poll"],
+							Validators: []validator.List{
+								listvalidator.SizeAtMost(1),
+							},
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"max_retries": retryMaxRetriesAttribute(),
+									"codes":       retryCodesAttribute(),
+									"base_delay":  retryDelayAttribute("retry_base_delay"),
+									"max_delay":   retryDelayAttribute("retry_max_delay"),
+								},
+							},
+						},
+						"service": schema.ListNestedBlock{
+							Description: common.Descriptions["retry_service"],
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Required:    true,
+										Description: common.Descriptions["retry_service_name"],
+									},
+									"max_retries": retryMaxRetriesAttribute(),
+									"codes":       retryCodesAttribute(),
+									"base_delay":  retryDelayAttribute("retry_base_delay"),
+									"max_delay":   retryDelayAttribute("retry_max_delay"),
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+func rateLimitRequestsPerSecondAttribute() schema.Float64Attribute {
+	return schema.Float64Attribute{
+		Optional:    true,
+		Description: common.Descriptions["rate_limit_requests_per_second"],
+	}
+}
+
+func rateLimitMaxInFlightAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:    true,
+		Description: common.Descriptions["rate_limit_max_in_flight"],
+	}
+}
+
+func retryCodesAttribute() schema.ListAttribute {
+	return schema.ListAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		Description: common.Descriptions["retry_codes"],
+	}
+}
+
+func retryDelayAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: common.Descriptions[description],
+	}
+}
+
+func retryMaxRetriesAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:    true,
+		Description: common.Descriptions["retry_max_retries"],
+	}
+}
+
 func setToDefaultIfNeeded(field types.String, osEnvName string, defaultVal string) types.String {
 	if len(field.ValueString()) != 0 {
 		return field
@@ -196,15 +412,32 @@ func setToDefaultBoolIfNeeded(field types.Bool, osEnvName string, defaultVal boo
 	return field
 }
 
-func setDefaults(config provider_config.State) provider_config.State {
-	config.Endpoint = setToDefaultIfNeeded(config.Endpoint, "YC_ENDPOINT", common.DefaultEndpoint)
-	config.FolderID = setToDefaultIfNeeded(config.FolderID, "YC_FOLDER_ID", "")
-	config.CloudID = setToDefaultIfNeeded(config.CloudID, "YC_CLOUD_ID", "")
-	config.OrganizationID = setToDefaultIfNeeded(config.OrganizationID, "YC_ORGANIZATION_ID", "")
+// loadCLIProfile reads yc CLI profile if user has asked for it, and returns an empty profile otherwise,
+// so that its fields can be used as fallback values unconditionally.
+func loadCLIProfile(config provider_config.State) (*ycprofile.Profile, error) {
+	filename := setToDefaultIfNeeded(config.YCConfigFile, "YC_CLI_CONFIG_FILE", "").ValueString()
+	name := setToDefaultIfNeeded(config.YCProfile, "YC_CLI_PROFILE", "").ValueString()
+	if filename == "" && name == "" {
+		return &ycprofile.Profile{}, nil
+	}
+	return ycprofile.Load(filename, name)
+}
+
+func setDefaults(config provider_config.State, cliProfile *ycprofile.Profile) provider_config.State {
+	config.Endpoint = setToDefaultIfNeeded(config.Endpoint, "YC_ENDPOINT", ycprofile.FirstNonEmpty(cliProfile.Endpoint, common.DefaultEndpoint))
+	config.FolderID = setToDefaultIfNeeded(config.FolderID, "YC_FOLDER_ID", cliProfile.FolderID)
+	config.CloudID = setToDefaultIfNeeded(config.CloudID, "YC_CLOUD_ID", cliProfile.CloudID)
+	config.OrganizationID = setToDefaultIfNeeded(config.OrganizationID, "YC_ORGANIZATION_ID", cliProfile.OrganizationID)
 	config.Region = setToDefaultIfNeeded(config.Region, "YC_REGION", common.DefaultRegion)
-	config.Zone = setToDefaultIfNeeded(config.Zone, "YC_ZONE", "")
+	config.Zone = setToDefaultIfNeeded(config.Zone, "YC_ZONE", cliProfile.Zone)
 	config.Token = setToDefaultIfNeeded(config.Token, "YC_TOKEN", "")
 	config.ServiceAccountKeyFileOrContent = setToDefaultIfNeeded(config.ServiceAccountKeyFileOrContent, "YC_SERVICE_ACCOUNT_KEY_FILE", "")
+	config.OIDCToken = setToDefaultIfNeeded(config.OIDCToken, "YC_OIDC_TOKEN", "")
+	config.OIDCTokenFile = setToDefaultIfNeeded(config.OIDCTokenFile, "YC_OIDC_TOKEN_FILE", "")
+	config.OIDCServiceAccountID = setToDefaultIfNeeded(config.OIDCServiceAccountID, "YC_OIDC_SERVICE_ACCOUNT_ID", "")
+	config.OIDCTokenExchangeEndpoint = setToDefaultIfNeeded(config.OIDCTokenExchangeEndpoint, "YC_OIDC_TOKEN_EXCHANGE_ENDPOINT", common.DefaultTokenExchangeEndpoint)
+	config.ImpersonateServiceAccountID = setToDefaultIfNeeded(config.ImpersonateServiceAccountID, "YC_IMPERSONATE_SERVICE_ACCOUNT_ID", "")
+	config.AuditLogFile = setToDefaultIfNeeded(config.AuditLogFile, "YC_AUDIT_LOG_FILE", "")
 	config.StorageEndpoint = setToDefaultIfNeeded(config.StorageEndpoint, "YC_STORAGE_ENDPOINT_URL", common.DefaultStorageEndpoint)
 	config.StorageAccessKey = setToDefaultIfNeeded(config.StorageAccessKey, "YC_STORAGE_ACCESS_KEY", "")
 	config.StorageSecretKey = setToDefaultIfNeeded(config.StorageSecretKey, "YC_STORAGE_SECRET_KEY", "")
@@ -214,6 +447,7 @@ func setDefaults(config provider_config.State) provider_config.State {
 
 	config.Insecure = setToDefaultBoolIfNeeded(config.Insecure, "YC_INSECURE", false)
 	config.Plaintext = setToDefaultBoolIfNeeded(config.Plaintext, "YC_PLAINTEXT", false)
+	config.CAFile = setToDefaultIfNeeded(config.CAFile, "YC_CA_FILE", "")
 
 	if config.MaxRetries.IsUnknown() || config.MaxRetries.IsNull() {
 		config.MaxRetries = types.Int64Value(common.DefaultMaxRetries)
@@ -225,21 +459,144 @@ func setDefaults(config provider_config.State) provider_config.State {
 	return config
 }
 
+func buildRetryPolicy(ctx context.Context, config provider_config.State) (*retrypolicy.Policy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var defaults, operationPoll retrypolicy.RawSettings
+	var services []retrypolicy.RawSettings
+
+	if len(config.Retry) > 0 {
+		retry := config.Retry[0]
+		defaults = retrypolicy.RawSettings{
+			BaseDelay: retry.BaseDelay.ValueString(),
+			MaxDelay:  retry.MaxDelay.ValueString(),
+		}
+		diags.Append(retry.Codes.ElementsAs(ctx, &defaults.Codes, false)...)
+
+		if len(retry.OperationPoll) > 0 {
+			op := retry.OperationPoll[0]
+			operationPoll = retrypolicy.RawSettings{
+				MaxRetries: int(op.MaxRetries.ValueInt64()),
+				BaseDelay:  op.BaseDelay.ValueString(),
+				MaxDelay:   op.MaxDelay.ValueString(),
+			}
+			diags.Append(op.Codes.ElementsAs(ctx, &operationPoll.Codes, false)...)
+		}
+
+		for _, service := range retry.Service {
+			s := retrypolicy.RawSettings{
+				Service:    service.Name.ValueString(),
+				MaxRetries: int(service.MaxRetries.ValueInt64()),
+				BaseDelay:  service.BaseDelay.ValueString(),
+				MaxDelay:   service.MaxDelay.ValueString(),
+			}
+			diags.Append(service.Codes.ElementsAs(ctx, &s.Codes, false)...)
+			services = append(services, s)
+		}
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	policy, err := retrypolicy.Build(int(config.MaxRetries.ValueInt64()), defaults, operationPoll, services)
+	if err != nil {
+		diags.AddError("Invalid retry configuration", err.Error())
+	}
+	return policy, diags
+}
+
+func buildRateLimitPolicy(config provider_config.State) (*ratelimit.Policy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(config.RateLimit) == 0 {
+		return nil, diags
+	}
+
+	rateLimit := config.RateLimit[0]
+	var services []ratelimit.RawSettings
+	for _, service := range rateLimit.Service {
+		services = append(services, ratelimit.RawSettings{
+			Service: service.Name.ValueString(),
+			Settings: ratelimit.Settings{
+				RequestsPerSecond: service.RequestsPerSecond.ValueFloat64(),
+				MaxInFlight:       int(service.MaxInFlight.ValueInt64()),
+			},
+		})
+	}
+
+	policy, err := ratelimit.Build(ratelimit.Settings{
+		RequestsPerSecond: rateLimit.RequestsPerSecond.ValueFloat64(),
+		MaxInFlight:       int(rateLimit.MaxInFlight.ValueInt64()),
+	}, services)
+	if err != nil {
+		diags.AddError("Invalid rate_limit configuration", err.Error())
+	}
+	return policy, diags
+}
+
+func buildEndpoints(ctx context.Context, config provider_config.State) (endpoints.Overrides, diag.Diagnostics) {
+	var raw map[string]string
+	diags := config.Endpoints.ElementsAs(ctx, &raw, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	overrides, err := endpoints.Build(raw)
+	if err != nil {
+		diags.AddError("Invalid endpoints configuration", err.Error())
+	}
+	return overrides, diags
+}
+
 func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	// Unmarshal config
 	p.config = provider_config.Config{}
 	resp.Diagnostics.Append(req.Config.Get(ctx, &p.config.ProviderState)...)
 	p.config.UserAgent = types.StringValue(req.TerraformVersion)
-	p.config.ProviderState = setDefaults(p.config.ProviderState)
+
+	cliProfile, err := loadCLIProfile(p.config.ProviderState)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to load yc CLI profile", err.Error())
+		return
+	}
+	p.config.CLIProfile = cliProfile
+	p.config.ProviderState = setDefaults(p.config.ProviderState, cliProfile)
 	if p.emptyFolder {
 		p.config.ProviderState.FolderID = types.StringValue("")
 	}
 
+	retryPolicy, diags := buildRetryPolicy(ctx, p.config.ProviderState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	p.config.RetryPolicy = retryPolicy
+
+	rateLimitPolicy, diags := buildRateLimitPolicy(p.config.ProviderState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	p.config.RateLimitPolicy = rateLimitPolicy
+
+	overrides, diags := buildEndpoints(ctx, p.config.ProviderState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	p.config.Endpoints = overrides
+
+	if len(p.config.ProviderState.Tracing) > 0 {
+		p.config.Tracing = &tracing.Settings{
+			Endpoint: p.config.ProviderState.Tracing[0].OTLPEndpoint.ValueString(),
+			Insecure: p.config.ProviderState.Tracing[0].Insecure.ValueBool(),
+		}
+	}
+
 	if err := p.config.InitAndValidate(ctx, req.TerraformVersion, false); err != nil {
 		resp.Diagnostics.AddError("Failed to configure", err.Error())
 	}
 	resp.ResourceData = &p.config
 	resp.DataSourceData = &p.config
+	resp.EphemeralResourceData = &p.config
 }
 
 func (p *Provider) Resources(_ context.Context) []func() resource.Resource {
@@ -283,6 +640,25 @@ func (p *Provider) DataSources(_ context.Context) []func() datasource.DataSource
 	}
 }
 
+func (p *Provider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		token.NewEphemeralResource,
+		secretversion.NewEphemeralResource,
+		staticaccesskey.NewEphemeralResource,
+	}
+}
+
+func (p *Provider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewBuildCompositeIDFunction,
+		functions.NewParseCompositeIDFunction,
+		functions.NewBuildImportIDFunction,
+		functions.NewParseImportIDFunction,
+		functions.NewQueueURLToARNFunction,
+		functions.NewIsValidIDFunction,
+	}
+}
+
 func (p *Provider) GetConfig() provider_config.Config {
 	return p.config
 }
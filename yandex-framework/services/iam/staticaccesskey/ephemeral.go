@@ -0,0 +1,146 @@
+package staticaccesskey
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
+	provider_config "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider/config"
+)
+
+// privateKeyID is a key of private data with ID of the created access key, which is deleted on Close.
+const privateKeyID = "access_key_id"
+
+type staticAccessKeyEphemeralResource struct {
+	providerConfig *provider_config.Config
+}
+
+type StaticAccessKey struct {
+	ID               types.String `tfsdk:"id"`
+	ServiceAccountID types.String `tfsdk:"service_account_id"`
+	Description      types.String `tfsdk:"description"`
+	AccessKey        types.String `tfsdk:"access_key"`
+	SecretKey        types.String `tfsdk:"secret_key"`
+}
+
+func NewEphemeralResource() ephemeral.EphemeralResource {
+	return &staticAccessKeyEphemeralResource{}
+}
+
+func (r *staticAccessKeyEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_service_account_static_access_key"
+}
+
+func (r *staticAccessKeyEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerConfig, ok := req.ProviderData.(*provider_config.Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected EphemeralResource Configure Type",
+			fmt.Sprintf("Expected *provider_config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerConfig = providerConfig
+}
+
+func (r *staticAccessKeyEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"service_account_id": schema.StringAttribute{
+				Required: true,
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"access_key": schema.StringAttribute{
+				Computed: true,
+			},
+			"secret_key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (r *staticAccessKeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data StaticAccessKey
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := r.providerConfig.SDK.IAM().AWSCompatibility().AccessKey().Create(ctx, &awscompatibility.CreateAccessKeyRequest{
+		ServiceAccountId: data.ServiceAccountID.ValueString(),
+		Description:      data.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Open ephemeral resource",
+			"Error while requesting API to create static access key: "+err.Error(),
+		)
+		return
+	}
+
+	keyID, err := json.Marshal(key.GetAccessKey().GetId())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Open ephemeral resource", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyID, keyID)...)
+	if resp.Diagnostics.HasError() {
+		// Close is never called without the private data, so the key would leak.
+		if err := r.deleteAccessKey(ctx, key.GetAccessKey().GetId()); err != nil {
+			resp.Diagnostics.AddError("Failed to Open ephemeral resource", err.Error())
+		}
+		return
+	}
+
+	data.ID = types.StringValue(key.GetAccessKey().GetId())
+	data.AccessKey = types.StringValue(key.GetAccessKey().GetKeyId())
+	data.SecretKey = types.StringValue(key.GetSecret())
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *staticAccessKeyEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	raw, diags := req.Private.GetKey(ctx, privateKeyID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+
+	var keyID string
+	if err := json.Unmarshal(raw, &keyID); err != nil {
+		resp.Diagnostics.AddError("Failed to Close ephemeral resource", err.Error())
+		return
+	}
+
+	if err := r.deleteAccessKey(ctx, keyID); err != nil {
+		resp.Diagnostics.AddError("Failed to Close ephemeral resource", err.Error())
+	}
+}
+
+func (r *staticAccessKeyEphemeralResource) deleteAccessKey(ctx context.Context, keyID string) error {
+	tflog.Debug(ctx, "Deleting temporary static access key", map[string]interface{}{"id": keyID})
+	_, err := r.providerConfig.SDK.IAM().AWSCompatibility().AccessKey().Delete(ctx, &awscompatibility.DeleteAccessKeyRequest{
+		AccessKeyId: keyID,
+	})
+	if err != nil {
+		return fmt.Errorf("Error while requesting API to delete static access key %s: %s", keyID, err)
+	}
+	return nil
+}
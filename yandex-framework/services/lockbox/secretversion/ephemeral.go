@@ -0,0 +1,124 @@
+package secretversion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/lockbox/v1"
+	provider_config "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider/config"
+)
+
+type secretVersionEphemeralResource struct {
+	providerConfig *provider_config.Config
+}
+
+type SecretVersion struct {
+	SecretID  types.String `tfsdk:"secret_id"`
+	VersionID types.String `tfsdk:"version_id"`
+	Entries   types.List   `tfsdk:"entries"`
+}
+
+var entryAttributeTypes = map[string]attr.Type{
+	"key":        types.StringType,
+	"text_value": types.StringType,
+}
+
+func NewEphemeralResource() ephemeral.EphemeralResource {
+	return &secretVersionEphemeralResource{}
+}
+
+func (r *secretVersionEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lockbox_secret_version"
+}
+
+func (r *secretVersionEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerConfig, ok := req.ProviderData.(*provider_config.Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected EphemeralResource Configure Type",
+			fmt.Sprintf("Expected *provider_config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerConfig = providerConfig
+}
+
+func (r *secretVersionEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"secret_id": schema.StringAttribute{
+				Required: true,
+			},
+			"version_id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"entries": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Computed: true,
+						},
+						"text_value": schema.StringAttribute{
+							Computed:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *secretVersionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data SecretVersion
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, err := r.providerConfig.SDK.LockboxPayload().Payload().Get(ctx, &lockbox.GetPayloadRequest{
+		SecretId:  data.SecretID.ValueString(),
+		VersionId: data.VersionID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Open ephemeral resource",
+			"Error while requesting API to get Lockbox secret version payload: "+err.Error(),
+		)
+		return
+	}
+
+	entries := make([]attr.Value, 0, len(payload.GetEntries()))
+	for _, entry := range payload.GetEntries() {
+		value, diags := types.ObjectValue(entryAttributeTypes, map[string]attr.Value{
+			"key":        types.StringValue(entry.GetKey()),
+			"text_value": types.StringValue(entry.GetTextValue()),
+		})
+		resp.Diagnostics.Append(diags...)
+		entries = append(entries, value)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, diags := types.ListValue(types.ObjectType{AttrTypes: entryAttributeTypes}, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.VersionID = types.StringValue(payload.GetVersionId())
+	data.Entries = list
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -17,6 +17,13 @@ type User struct {
 	Permission types.Set    `tfsdk:"permission"`
 }
 
+// ResourceUser is a User with write-only password, which is available only in the resource configuration.
+type ResourceUser struct {
+	User
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+}
+
 type Permission struct {
 	DatabaseName types.String `tfsdk:"database_name"`
 	Roles        types.Set    `tfsdk:"roles"`
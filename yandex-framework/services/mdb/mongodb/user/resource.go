@@ -3,11 +3,18 @@ package user
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/mongodb/v1"
@@ -68,9 +75,23 @@ func (r *bindingResource) Schema(_ context.Context,
 				},
 			},
 			"password": schema.StringAttribute{
-				Required:  true,
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("password_wo")),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				Optional:  true,
+				WriteOnly: true,
 				Sensitive: true,
 			},
+			"password_wo_version": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"permission": schema.SetNestedBlock{
@@ -91,7 +112,7 @@ func (r *bindingResource) Schema(_ context.Context,
 }
 
 func (r *bindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state User
+	var state ResourceUser
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -103,7 +124,7 @@ func (r *bindingResource) Read(ctx context.Context, req resource.ReadRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(userToState(user, &state)...)
+	resp.Diagnostics.Append(userToState(user, &state.User)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -114,7 +135,7 @@ func (r *bindingResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *bindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan User
+	var plan ResourceUser
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -122,11 +143,17 @@ func (r *bindingResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	cid := plan.ClusterID.ValueString()
-	userPlan, diags := userFromState(ctx, &plan)
+	userPlan, diags := userFromState(ctx, &plan.User)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if userPlan.Password == "" {
+		userPlan.Password = passwordWOFromConfig(ctx, req.Config, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	createUser(ctx, r.providerConfig.SDK, &resp.Diagnostics, cid, userPlan)
 	if resp.Diagnostics.HasError() {
@@ -138,6 +165,13 @@ func (r *bindingResource) Create(ctx context.Context, req resource.CreateRequest
 	resp.Diagnostics.Append(diags...)
 }
 
+// passwordWOFromConfig returns write-only password, which is available only in configuration during apply.
+func passwordWOFromConfig(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) string {
+	var password types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("password_wo"), &password)...)
+	return password.ValueString()
+}
+
 func getUpdatePaths(plan, state *mongodb.UserSpec) []string {
 	var updatePaths []string
 	if state.Password != plan.Password {
@@ -150,8 +184,8 @@ func getUpdatePaths(plan, state *mongodb.UserSpec) []string {
 }
 
 func (r *bindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan User
-	var state User
+	var plan ResourceUser
+	var state ResourceUser
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -159,14 +193,24 @@ func (r *bindingResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	cid := plan.ClusterID.ValueString()
-	userState, diags := userFromState(ctx, &state)
+	userState, diags := userFromState(ctx, &state.User)
 	resp.Diagnostics.Append(diags...)
-	userPlan, diags := userFromState(ctx, &plan)
+	userPlan, diags := userFromState(ctx, &plan.User)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	updatePaths := getUpdatePaths(userPlan, userState)
+	// Write-only password is never stored, so it is sent only when its version is bumped.
+	if !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) && !slices.Contains(updatePaths, "password") {
+		updatePaths = append(updatePaths, "password")
+	}
+	if userPlan.Password == "" {
+		userPlan.Password = passwordWOFromConfig(ctx, req.Config, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	if len(updatePaths) > 0 {
 		updateUser(ctx, r.providerConfig.SDK, &resp.Diagnostics, cid, userPlan, updatePaths)
@@ -206,8 +250,8 @@ func (r *bindingResource) ImportState(ctx context.Context, req resource.ImportSt
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	var state User
-	resp.Diagnostics.Append(userToState(user, &state)...)
+	var state ResourceUser
+	resp.Diagnostics.Append(userToState(user, &state.User)...)
 
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
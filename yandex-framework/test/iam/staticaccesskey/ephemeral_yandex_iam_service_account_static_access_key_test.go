@@ -0,0 +1,59 @@
+package staticaccesskey
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/test"
+)
+
+func TestAccEphemeralIAMServiceAccountStaticAccessKey_basic(t *testing.T) {
+	t.Parallel()
+
+	saName := acctest.RandomWithPrefix("tf-sa-ephemeral")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { test.AccPreCheck(t) },
+		ProtoV6ProviderFactories: test.AccEphemeralProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEphemeralIAMServiceAccountStaticAccessKeyConfig(saName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.key", tfjsonpath.New("data").AtMapKey("access_key"), knownvalue.StringRegexp(regexp.MustCompile(`^\S+$`))),
+					statecheck.ExpectKnownValue("echo.key", tfjsonpath.New("data").AtMapKey("secret_key"), knownvalue.StringRegexp(regexp.MustCompile(`^\S+$`))),
+					statecheck.ExpectKnownValue("echo.key", tfjsonpath.New("data").AtMapKey("description"), knownvalue.StringExact("temporary key")),
+				},
+			},
+		},
+	})
+}
+
+func testAccEphemeralIAMServiceAccountStaticAccessKeyConfig(saName string) string {
+	return fmt.Sprintf(`
+resource "yandex_iam_service_account" "sa" {
+  name      = "%s"
+  folder_id = "%s"
+}
+
+ephemeral "yandex_iam_service_account_static_access_key" "key" {
+  service_account_id = yandex_iam_service_account.sa.id
+  description        = "temporary key"
+}
+
+provider "echo" {
+  data = ephemeral.yandex_iam_service_account_static_access_key.key
+}
+
+resource "echo" "key" {}
+`, saName, test.GetExampleFolderID())
+}
@@ -0,0 +1,76 @@
+package secretversion
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/test"
+)
+
+func TestAccEphemeralLockboxSecretVersion_basic(t *testing.T) {
+	t.Parallel()
+
+	secretName := acctest.RandomWithPrefix("tf-lockbox-ephemeral")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { test.AccPreCheck(t) },
+		ProtoV6ProviderFactories: test.AccEphemeralProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEphemeralLockboxSecretVersionConfig(secretName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.payload", tfjsonpath.New("data").AtMapKey("entries"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"key":        knownvalue.StringExact("key1"),
+							"text_value": knownvalue.StringExact("val1"),
+						}),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"key":        knownvalue.StringExact("key2"),
+							"text_value": knownvalue.StringExact("val2"),
+						}),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func testAccEphemeralLockboxSecretVersionConfig(secretName string) string {
+	return fmt.Sprintf(`
+resource "yandex_lockbox_secret" "secret" {
+  name = "%s"
+}
+
+resource "yandex_lockbox_secret_version" "version" {
+  secret_id = yandex_lockbox_secret.secret.id
+  entries {
+    key        = "key1"
+    text_value = "val1"
+  }
+  entries {
+    key        = "key2"
+    text_value = "val2"
+  }
+}
+
+ephemeral "yandex_lockbox_secret_version" "payload" {
+  secret_id  = yandex_lockbox_secret.secret.id
+  version_id = yandex_lockbox_secret_version.version.id
+}
+
+provider "echo" {
+  data = ephemeral.yandex_lockbox_secret_version.payload
+}
+
+resource "echo" "payload" {}
+`, secretName)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/stretchr/testify/assert"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/mongodb/v1"
 	yandex_framework "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider"
@@ -66,6 +67,37 @@ func TestAccMDBMongoDBUser_full(t *testing.T) {
 	})
 }
 
+// Test that a MongoDB User can be created with write-only password and the password can be rotated
+func TestAccMDBMongoDBUser_writeOnlyPassword(t *testing.T) {
+	t.Parallel()
+	clusterName := acctest.RandomWithPrefix("tf-mongodb-user-wo")
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		PreCheck:                 func() { test.AccPreCheck(t) },
+		ProtoV6ProviderFactories: test.AccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMDBMongoDBUserConfigWriteOnlyPassword(clusterName, "mysecureP@ssw0rd", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(mgUserResourceNameAlice, "name", "alice"),
+					resource.TestCheckResourceAttr(mgUserResourceNameAlice, "password_wo_version", "1"),
+					resource.TestCheckNoResourceAttr(mgUserResourceNameAlice, "password"),
+					resource.TestCheckNoResourceAttr(mgUserResourceNameAlice, "password_wo"),
+				),
+			},
+			{
+				Config: testAccMDBMongoDBUserConfigWriteOnlyPassword(clusterName, "myRotatedP@ssw0rd", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(mgUserResourceNameAlice, "password_wo_version", "2"),
+					resource.TestCheckNoResourceAttr(mgUserResourceNameAlice, "password_wo"),
+				),
+			},
+		},
+	})
+}
+
 func mdbMongoDBUserImportStep(name string) resource.TestStep {
 	return resource.TestStep{
 		ResourceName:      name,
@@ -193,3 +225,14 @@ resource "yandex_mdb_mongodb_user" "alice" {
   	}
 }`
 }
+
+// Create user with write-only password
+func testAccMDBMongoDBUserConfigWriteOnlyPassword(name, password string, version int) string {
+	return testAccMDBMongoDBUserConfigStep0(name) + fmt.Sprintf(`
+resource "yandex_mdb_mongodb_user" "alice" {
+	cluster_id          = yandex_mdb_mongodb_cluster.foo.id
+	name                = "alice"
+	password_wo         = "%s"
+	password_wo_version = %d
+}`, password, version)
+}
@@ -64,6 +64,7 @@ func ConfigForSweepers() (*provider_config.Config, error) {
 			FolderID:                       types.StringValue(folderID),
 			Endpoint:                       types.StringValue(os.Getenv("YC_ENDPOINT")),
 			StorageEndpoint:                types.StringValue(os.Getenv("YC_STORAGE_ENDPOINT_URL")),
+			ImpersonateServiceAccountID:    types.StringValue(os.Getenv("YC_IMPERSONATE_SERVICE_ACCOUNT_ID")),
 		},
 	}
 
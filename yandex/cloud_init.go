@@ -0,0 +1,403 @@
+package yandex
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	cloudInitUserDataKey = "user-data"
+
+	// Boundary is fixed, so that the same cloud_init block is always rendered into the same user-data.
+	cloudInitBoundary = "==YANDEX-CLOUD-INIT=="
+
+	// Compute Cloud limits the total size of instance metadata.
+	cloudInitMaxMetadataSize = 512 * 1024
+)
+
+var cloudInitFilePermissionsRegexp = regexp.MustCompile(`^0?[0-7]{3,4}$`)
+
+var cloudInitPartContentTypes = []string{
+	"text/cloud-config",
+	"text/cloud-boothook",
+	"text/jinja2",
+	"text/part-handler",
+	"text/upstart-job",
+	"text/x-include-url",
+	"text/x-shellscript",
+}
+
+func cloudInitSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"users": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"groups": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+							"sudo": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"shell": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"ssh_authorized_keys": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+				"ssh_authorized_keys": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"package_update": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"packages": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"write_files": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"path": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"content": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"permissions": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.StringMatch(cloudInitFilePermissionsRegexp, "must be octal file permissions, e.g. 0644"),
+							},
+							"owner": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"append": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"runcmd": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"extra_config": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateCloudInitExtraConfig,
+				},
+				"part": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"content_type": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringInSlice(cloudInitPartContentTypes, false),
+							},
+							"content": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"filename": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// renderCloudInit renders cloud_init block into multipart user-data. It returns empty string if the block is not set.
+func renderCloudInit(v interface{}) (string, error) {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return "", nil
+	}
+	cloudInit := list[0].(map[string]interface{})
+
+	cloudConfig, err := expandCloudInitConfig(cloudInit)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("Content-Type: multipart/mixed; boundary=\"" + cloudInitBoundary + "\"\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n\r\n")
+
+	w := multipart.NewWriter(&buf)
+	if err := w.SetBoundary(cloudInitBoundary); err != nil {
+		return "", err
+	}
+
+	if cloudConfig != "" {
+		if err := writeCloudInitPart(w, "text/cloud-config", "cloud-config.yaml", cloudConfig); err != nil {
+			return "", err
+		}
+	}
+	for i, raw := range cloudInit["part"].([]interface{}) {
+		part := raw.(map[string]interface{})
+		filename := part["filename"].(string)
+		if filename == "" {
+			filename = fmt.Sprintf("part-%03d", i+1)
+		}
+		if err := writeCloudInitPart(w, part["content_type"].(string), filename, part["content"].(string)); err != nil {
+			return "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func writeCloudInitPart(w *multipart.Writer, contentType, filename, content string) error {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType+"; charset=\"utf-8\"")
+	header.Set("MIME-Version", "1.0")
+	header.Set("Content-Transfer-Encoding", "7bit")
+	header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = part.Write([]byte(content))
+	return err
+}
+
+// expandCloudInitConfig renders the structured part of cloud_init block into #cloud-config document.
+func expandCloudInitConfig(cloudInit map[string]interface{}) (string, error) {
+	config := make(map[string]interface{})
+
+	if v := cloudInit["extra_config"].(string); v != "" {
+		if err := yaml.Unmarshal([]byte(v), &config); err != nil {
+			return "", fmt.Errorf("invalid cloud_init extra_config: %s", err)
+		}
+	}
+
+	set := func(key string, value interface{}) error {
+		if _, ok := config[key]; ok {
+			return fmt.Errorf("cloud_init extra_config must not contain %q, use the corresponding argument instead", key)
+		}
+		config[key] = value
+		return nil
+	}
+
+	if users := cloudInit["users"].([]interface{}); len(users) > 0 {
+		var values []interface{}
+		for _, raw := range users {
+			user := raw.(map[string]interface{})
+			value := map[string]interface{}{"name": user["name"].(string)}
+			if groups := expandCloudInitStringList(user["groups"]); len(groups) > 0 {
+				value["groups"] = groups
+			}
+			if sudo := user["sudo"].(string); sudo != "" {
+				value["sudo"] = sudo
+			}
+			if shell := user["shell"].(string); shell != "" {
+				value["shell"] = shell
+			}
+			if keys := expandCloudInitStringList(user["ssh_authorized_keys"]); len(keys) > 0 {
+				value["ssh_authorized_keys"] = keys
+			}
+			values = append(values, value)
+		}
+		if err := set("users", values); err != nil {
+			return "", err
+		}
+	}
+
+	if keys := expandCloudInitStringList(cloudInit["ssh_authorized_keys"]); len(keys) > 0 {
+		if err := set("ssh_authorized_keys", keys); err != nil {
+			return "", err
+		}
+	}
+
+	if cloudInit["package_update"].(bool) {
+		if err := set("package_update", true); err != nil {
+			return "", err
+		}
+	}
+
+	if packages := expandCloudInitStringList(cloudInit["packages"]); len(packages) > 0 {
+		if err := set("packages", packages); err != nil {
+			return "", err
+		}
+	}
+
+	if files := cloudInit["write_files"].([]interface{}); len(files) > 0 {
+		var values []interface{}
+		for _, raw := range files {
+			file := raw.(map[string]interface{})
+			value := map[string]interface{}{
+				"path":    file["path"].(string),
+				"content": file["content"].(string),
+			}
+			if permissions := file["permissions"].(string); permissions != "" {
+				value["permissions"] = permissions
+			}
+			if owner := file["owner"].(string); owner != "" {
+				value["owner"] = owner
+			}
+			if file["append"].(bool) {
+				value["append"] = true
+			}
+			values = append(values, value)
+		}
+		if err := set("write_files", values); err != nil {
+			return "", err
+		}
+	}
+
+	if commands := expandCloudInitStringList(cloudInit["runcmd"]); len(commands) > 0 {
+		if err := set("runcmd", commands); err != nil {
+			return "", err
+		}
+	}
+
+	if len(config) == 0 {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to render cloud_init: %s", err)
+	}
+	return "#cloud-config\n" + string(out), nil
+}
+
+func expandCloudInitStringList(v interface{}) []string {
+	list, _ := v.([]interface{})
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func validateCloudInitExtraConfig(v interface{}, k string) (ws []string, errors []error) {
+	var config map[string]interface{}
+	if err := yaml.Unmarshal([]byte(v.(string)), &config); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a YAML mapping: %s", k, err))
+	}
+	return
+}
+
+// expandMetadataWithCloudInit adds user-data rendered from cloud_init block to the metadata.
+func expandMetadataWithCloudInit(metadata map[string]string, cloudInit interface{}) (map[string]string, error) {
+	userData, err := renderCloudInit(cloudInit)
+	if err != nil {
+		return nil, err
+	}
+	if userData == "" {
+		return metadata, nil
+	}
+	if _, ok := metadata[cloudInitUserDataKey]; ok {
+		return nil, fmt.Errorf("cloud_init conflicts with %q key of metadata, only one of them can be specified", cloudInitUserDataKey)
+	}
+
+	result := make(map[string]string, len(metadata)+1)
+	for k, v := range metadata {
+		result[k] = v
+	}
+	result[cloudInitUserDataKey] = userData
+
+	var size int
+	for k, v := range result {
+		size += len(k) + len(v)
+	}
+	if size > cloudInitMaxMetadataSize {
+		return nil, fmt.Errorf("metadata with user-data rendered from cloud_init is %d bytes, which exceeds the limit of %d bytes", size, cloudInitMaxMetadataSize)
+	}
+
+	return result, nil
+}
+
+// flattenMetadataWithCloudInit hides user-data managed by cloud_init block, so that its changes are shown
+// as a diff of the block rather than of the rendered string. User-data changed outside of Terraform is kept
+// to be reverted on the next apply.
+func flattenMetadataWithCloudInit(metadata map[string]string, cloudInit interface{}) map[string]string {
+	userData, err := renderCloudInit(cloudInit)
+	if err != nil || userData == "" || metadata[cloudInitUserDataKey] != userData {
+		return metadata
+	}
+
+	result := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		if k != cloudInitUserDataKey {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// cloudInitCustomizeDiff validates cloud_init block at plan time. Prefix points to the block containing
+// cloud_init and metadata attributes, e.g. "instance_template.0.".
+func cloudInitCustomizeDiff(prefix string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		key := prefix + "cloud_init"
+		if _, ok := d.GetOk(key); !ok {
+			return nil
+		}
+		if !d.NewValueKnown(key) || !d.NewValueKnown(prefix+"metadata") {
+			return nil
+		}
+
+		metadata, err := expandLabels(d.Get(prefix + "metadata"))
+		if err != nil {
+			return err
+		}
+		if _, err := expandMetadataWithCloudInit(metadata, d.Get(key)); err != nil {
+			return fmt.Errorf("invalid %s: %s", strings.TrimSuffix(key, ".0"), err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,161 @@
+package yandex
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func testCloudInitValue(t *testing.T, cloudInit map[string]interface{}) interface{} {
+	raw := map[string]interface{}{
+		"cloud_init": []interface{}{cloudInit},
+	}
+	d := schema.TestResourceDataRaw(t, resourceYandexComputeInstance().Schema, raw)
+	return d.Get("cloud_init")
+}
+
+func TestRenderCloudInit(t *testing.T) {
+	cloudInit := testCloudInitValue(t, map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{
+				"name":                "ubuntu",
+				"groups":              []interface{}{"sudo"},
+				"sudo":                "ALL=(ALL) NOPASSWD:ALL",
+				"shell":               "/bin/bash",
+				"ssh_authorized_keys": []interface{}{"ssh-ed25519 AAAA ubuntu"},
+			},
+		},
+		"package_update": true,
+		"packages":       []interface{}{"nginx", "curl"},
+		"write_files": []interface{}{
+			map[string]interface{}{
+				"path":        "/etc/motd",
+				"content":     "hello\n",
+				"permissions": "0644",
+			},
+		},
+		"runcmd":       []interface{}{"systemctl restart nginx"},
+		"extra_config": "timezone: Europe/Moscow\n",
+		"part": []interface{}{
+			map[string]interface{}{
+				"content_type": "text/x-shellscript",
+				"content":      "#!/bin/sh\necho done\n",
+			},
+		},
+	})
+
+	userData, err := renderCloudInit(cloudInit)
+	require.NoError(t, err)
+
+	again, err := renderCloudInit(cloudInit)
+	require.NoError(t, err)
+	assert.Equal(t, userData, again, "rendering must be deterministic")
+
+	assert.True(t, strings.HasPrefix(userData, "Content-Type: multipart/mixed; boundary=\""+cloudInitBoundary+"\""))
+	assert.Contains(t, userData, "Content-Type: text/cloud-config")
+	assert.Contains(t, userData, "Content-Type: text/x-shellscript")
+	assert.Contains(t, userData, "filename=\"part-001\"")
+	assert.Less(t, strings.Index(userData, "#cloud-config"), strings.Index(userData, "#!/bin/sh"))
+
+	cloudConfig, err := expandCloudInitConfig(cloudInit.([]interface{})[0].(map[string]interface{}))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(cloudConfig, "#cloud-config\n"))
+
+	var config map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(cloudConfig), &config))
+	assert.Equal(t, "Europe/Moscow", config["timezone"])
+	assert.Equal(t, true, config["package_update"])
+	assert.Equal(t, []interface{}{"nginx", "curl"}, config["packages"])
+	assert.Equal(t, []interface{}{"systemctl restart nginx"}, config["runcmd"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":                "ubuntu",
+			"groups":              []interface{}{"sudo"},
+			"sudo":                "ALL=(ALL) NOPASSWD:ALL",
+			"shell":               "/bin/bash",
+			"ssh_authorized_keys": []interface{}{"ssh-ed25519 AAAA ubuntu"},
+		},
+	}, config["users"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"path":        "/etc/motd",
+			"content":     "hello\n",
+			"permissions": "0644",
+		},
+	}, config["write_files"])
+}
+
+func TestRenderCloudInitEmpty(t *testing.T) {
+	userData, err := renderCloudInit([]interface{}{})
+	require.NoError(t, err)
+	assert.Empty(t, userData)
+
+	d := schema.TestResourceDataRaw(t, resourceYandexComputeInstance().Schema, map[string]interface{}{})
+	userData, err = renderCloudInit(d.Get("cloud_init"))
+	require.NoError(t, err)
+	assert.Empty(t, userData)
+}
+
+func TestRenderCloudInitExtraConfigCollision(t *testing.T) {
+	cloudInit := testCloudInitValue(t, map[string]interface{}{
+		"packages":     []interface{}{"nginx"},
+		"extra_config": "packages: [curl]\n",
+	})
+
+	_, err := renderCloudInit(cloudInit)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"packages"`)
+}
+
+func TestValidateCloudInitExtraConfig(t *testing.T) {
+	_, errs := validateCloudInitExtraConfig("timezone: UTC\nlocale: en_US.UTF-8\n", "extra_config")
+	assert.Empty(t, errs)
+
+	_, errs = validateCloudInitExtraConfig("- not\n- a mapping\n", "extra_config")
+	assert.NotEmpty(t, errs)
+}
+
+func TestExpandMetadataWithCloudInit(t *testing.T) {
+	cloudInit := testCloudInitValue(t, map[string]interface{}{
+		"packages": []interface{}{"nginx"},
+	})
+
+	metadata, err := expandMetadataWithCloudInit(map[string]string{"serial-port-enable": "1"}, cloudInit)
+	require.NoError(t, err)
+	assert.Equal(t, "1", metadata["serial-port-enable"])
+	assert.Contains(t, metadata[cloudInitUserDataKey], "- nginx")
+
+	_, err = expandMetadataWithCloudInit(map[string]string{cloudInitUserDataKey: "#cloud-config\n"}, cloudInit)
+	require.Error(t, err)
+
+	_, err = expandMetadataWithCloudInit(map[string]string{"large": strings.Repeat("x", cloudInitMaxMetadataSize)}, cloudInit)
+	require.Error(t, err)
+
+	metadata, err = expandMetadataWithCloudInit(map[string]string{cloudInitUserDataKey: "#cloud-config\n"}, []interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{cloudInitUserDataKey: "#cloud-config\n"}, metadata)
+}
+
+func TestFlattenMetadataWithCloudInit(t *testing.T) {
+	cloudInit := testCloudInitValue(t, map[string]interface{}{
+		"runcmd": []interface{}{"echo hello"},
+	})
+	userData, err := renderCloudInit(cloudInit)
+	require.NoError(t, err)
+
+	metadata := flattenMetadataWithCloudInit(map[string]string{
+		"ssh-keys":           "ubuntu:ssh-ed25519 AAAA",
+		cloudInitUserDataKey: userData,
+	}, cloudInit)
+	assert.Equal(t, map[string]string{"ssh-keys": "ubuntu:ssh-ed25519 AAAA"}, metadata)
+
+	// User-data changed outside of Terraform is kept to show the diff
+	metadata = flattenMetadataWithCloudInit(map[string]string{
+		cloudInitUserDataKey: "#cloud-config\n",
+	}, cloudInit)
+	assert.Equal(t, map[string]string{cloudInitUserDataKey: "#cloud-config\n"}, metadata)
+}
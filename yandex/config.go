@@ -2,14 +2,12 @@ package yandex
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
-	"math"
-	"math/rand"
 	"net"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -18,25 +16,27 @@ import (
 	"github.com/google/uuid"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/mitchellh/go-homedir"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
 	ycsdk "github.com/yandex-cloud/go-sdk"
 	"github.com/yandex-cloud/go-sdk/iamkey"
 	"github.com/yandex-cloud/go-sdk/pkg/requestid"
-	"github.com/yandex-cloud/go-sdk/pkg/retry"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 
 	"github.com/yandex-cloud/terraform-provider-yandex/pkg/config"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/endpoints"
 	"github.com/yandex-cloud/terraform-provider-yandex/pkg/logging"
-)
-
-const (
-	defaultExponentialBackoffBase = 50 * time.Millisecond
-	defaultExponentialBackoffCap  = 1 * time.Minute
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ratelimit"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/retrypolicy"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tokenexchange"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tracing"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/transport"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ycprofile"
 )
 
 type Config struct {
 	Endpoint                       string
+	Endpoints                      endpoints.Overrides
 	FolderID                       string
 	CloudID                        string
 	OrganizationID                 string
@@ -46,10 +46,25 @@ type Config struct {
 	Plaintext                      bool
 	Insecure                       bool
 	MaxRetries                     int
+	RetryPolicy                    *retrypolicy.Policy
+	RateLimitPolicy                *ratelimit.Policy
+	AuditLogFile                   string
+	Tracing                        *tracing.Settings
+	Transport                      transport.Settings
 	StorageEndpoint                string
 	YMQEndpoint                    string
 	Region                         string
 
+	// OIDC JWT (or a file with it) exchanged for IAM token of OIDCServiceAccountID
+	// via workload identity federation.
+	OIDCToken                 string
+	OIDCTokenFile             string
+	OIDCServiceAccountID      string
+	OIDCTokenExchangeEndpoint string
+
+	// ImpersonateServiceAccountID is an ID of the service account every API call is made on behalf of.
+	ImpersonateServiceAccountID string
+
 	// These storage access keys are optional and only used when
 	// storage data/resource doesn't have own access keys explicitly specified.
 	StorageAccessKey string
@@ -60,17 +75,33 @@ type Config struct {
 	YMQAccessKey string
 	YMQSecretKey string
 
+	// AllowTemporaryAccessKeys allows creating temporary static access keys for Object Storage
+	// and Message Queue operations, if no access keys are specified.
+	AllowTemporaryAccessKeys bool
+
+	// StorageBucketStandaloneConfigurations makes yandex_storage_bucket refresh the configurations, which may be
+	// managed by standalone resources, only if they are set in its state.
+	StorageBucketStandaloneConfigurations bool
+
 	SharedCredentialsFile string
 	Profile               string
 
+	// cliProfile is yc CLI profile used as a fallback source of credentials and defaults.
+	cliProfile *ycprofile.Profile
+
 	// contextWithClientTraceID is a context that has client-trace-id in its metadata
 	// It is initialized from stopContext at the same time as ycsdk.SDK
 	contextWithClientTraceID context.Context
 
 	userAgent         string
 	sdk               *ycsdk.SDK
+	auditLog          *logging.AuditLog
+	httpTransport     http.RoundTripper
 	sharedCredentials *SharedCredentials
 	defaultS3Session  *session.Session
+
+	// temporaryStaticAccessKeys are used by S3-compatible APIs when no static keys are specified.
+	temporaryStaticAccessKeys *temporaryStaticAccessKeys
 }
 
 // this function return context with added client trace id
@@ -87,18 +118,41 @@ func (c *Config) ContextWithTimeout(timeout time.Duration) (context.Context, con
 func (c *Config) initAndValidate(stopContext context.Context, terraformVersion string, sweeper bool) error {
 	c.contextWithClientTraceID = requestid.ContextWithClientTraceID(stopContext, uuid.New().String())
 
+	if err := c.Transport.Validate(); err != nil {
+		return err
+	}
+	if err := c.Transport.SetYDBEnvironment(); err != nil {
+		return err
+	}
+
+	if err := tracing.Setup(c.Tracing); err != nil {
+		return err
+	}
+
+	// HTTP transport is built ahead of credentials, since token exchange goes through it.
+	if !c.Transport.IsZero() {
+		httpTransport, err := c.Transport.HTTPTransport()
+		if err != nil {
+			return err
+		}
+		c.httpTransport = httpTransport
+	}
+
 	credentials, err := c.credentials()
 	if err != nil {
 		return err
 	}
 
+	tlsConfig, err := c.Transport.TLSConfig(c.Insecure)
+	if err != nil {
+		return err
+	}
+
 	yandexSDKConfig := &ycsdk.Config{
 		Credentials: credentials,
 		Endpoint:    c.Endpoint,
 		Plaintext:   c.Plaintext,
-		TLSConfig: &tls.Config{
-			InsecureSkipVerify: c.Insecure,
-		},
+		TLSConfig:   tlsConfig,
 	}
 
 	c.userAgent = config.BuildUserAgent(terraformVersion, sweeper)
@@ -107,15 +161,32 @@ func (c *Config) initAndValidate(stopContext context.Context, terraformVersion s
 
 	requestIDInterceptor := requestid.Interceptor()
 
-	retryInterceptor := retry.Interceptor(
-		retry.WithMax(c.MaxRetries),
-		retry.WithCodes(codes.Unavailable),
-		retry.WithAttemptHeader(true),
-		retry.WithBackoff(backoffExponentialWithJitter(defaultExponentialBackoffBase, defaultExponentialBackoffCap)))
+	retryPolicy := c.RetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = retrypolicy.NewPolicy(c.MaxRetries)
+	}
+	retryInterceptor := retryPolicy.Interceptor()
 
-	var interceptors = []grpc.UnaryClientInterceptor{
-		retryInterceptor,
-		requestIDInterceptor,
+	var interceptors []grpc.UnaryClientInterceptor
+	if tracing.Enabled() {
+		interceptors = append(interceptors, tracing.UnaryClientInterceptor(), retryInterceptor, tracing.AttemptInterceptor())
+	} else {
+		interceptors = append(interceptors, retryInterceptor)
+	}
+	if c.RateLimitPolicy != nil {
+		interceptors = append(interceptors, c.RateLimitPolicy.Interceptor())
+	}
+	interceptors = append(interceptors, requestIDInterceptor)
+	if len(c.Endpoints) > 0 {
+		interceptors = append(interceptors, c.Endpoints.Interceptor())
+	}
+
+	if c.AuditLogFile != "" {
+		c.auditLog, err = logging.OpenAuditLog(c.AuditLogFile)
+		if err != nil {
+			return err
+		}
+		interceptors = append(interceptors, c.auditLog.UnaryInterceptor())
 	}
 
 	// Support deep API logging in case user has requested it.
@@ -128,13 +199,29 @@ func (c *Config) initAndValidate(stopContext context.Context, terraformVersion s
 	// Now we will have new request id for every retry attempt.
 	interceptorChain := grpc_middleware.ChainUnaryClient(interceptors...)
 
-	c.sdk, err = ycsdk.Build(c.contextWithClientTraceID, *yandexSDKConfig,
+	callOptions := []grpc.CallOption{grpc.Header(&headerMD)}
+	if c.ImpersonateServiceAccountID != "" {
+		// SDK exchanges base credentials for IAM token of the service account and caches it until expiration.
+		callOptions = append(callOptions, ycsdk.WithAuthAsServiceAccount(c.ImpersonateServiceAccountID))
+	}
+
+	dialOptions := []grpc.DialOption{
 		grpc.WithUserAgent(c.userAgent),
-		grpc.WithDefaultCallOptions(grpc.Header(&headerMD)),
-		grpc.WithUnaryInterceptor(interceptorChain))
+		grpc.WithDefaultCallOptions(callOptions...),
+		grpc.WithUnaryInterceptor(interceptorChain),
+	}
+	if c.Transport.HTTPProxy != "" {
+		dialOptions = append(dialOptions, grpc.WithContextDialer(c.Transport.GRPCDialer()))
+	}
+	if len(c.Endpoints) > 0 {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(c.Endpoints.TransportCredentials(tlsConfig, c.Plaintext)))
+	}
+
+	c.sdk, err = ycsdk.Build(c.contextWithClientTraceID, *yandexSDKConfig, dialOptions...)
 	if err != nil {
 		return err
 	}
+	c.temporaryStaticAccessKeys = newTemporaryStaticAccessKeys(c)
 
 	err = c.initSharedCredentials()
 	if err != nil {
@@ -176,11 +263,40 @@ func (c *Config) initializeDefaultS3Client() (err error) {
 		return fmt.Errorf("both storage access key and storage secret key should be specified or not specified")
 	}
 
-	c.defaultS3Session, err = newS3Session(c.StorageEndpoint, accessKey, secretKey)
+	c.defaultS3Session, err = newS3Session(c.StorageEndpoint, accessKey, secretKey, c.httpClient(storageServiceName, logging.AuditProtocolS3))
 
 	return err
 }
 
+// httpClient returns HTTP client for S3-compatible APIs of the service, which are not called via gRPC
+// and hence not covered by interceptors. It returns nil if AWS SDK default client is fine.
+func (c *Config) httpClient(service, auditProtocol string) *http.Client {
+	var transport http.RoundTripper = http.DefaultTransport
+	if c.httpTransport != nil {
+		transport = c.httpTransport
+	}
+	transport = ratelimit.Transport(c.RateLimitPolicy.ServiceLimiter(service), transport)
+	if c.auditLog != nil {
+		transport = c.auditLog.Transport(auditProtocol, transport)
+	}
+	if tracing.Enabled() {
+		transport = tracing.Transport(auditProtocol, transport)
+	}
+	if transport == http.DefaultTransport {
+		return nil
+	}
+	return &http.Client{Transport: transport}
+}
+
+// createIAMToken returns IAM token for clients that are not built on top of SDK connection (e.g. YDB).
+// The token belongs to the impersonated service account if there is one.
+func (c *Config) createIAMToken(ctx context.Context) (*iam.CreateIamTokenResponse, error) {
+	if c.ImpersonateServiceAccountID != "" {
+		return c.sdk.CreateIAMTokenForServiceAccount(ctx, c.ImpersonateServiceAccountID)
+	}
+	return c.sdk.CreateIAMToken(ctx)
+}
+
 func (c *Config) credentials() (ycsdk.Credentials, error) {
 	if c.ServiceAccountKeyFileOrContent != "" {
 		contents, _, err := pathOrContents(c.ServiceAccountKeyFileOrContent)
@@ -202,11 +318,23 @@ func (c *Config) credentials() (ycsdk.Credentials, error) {
 		return ycsdk.OAuthToken(c.Token), nil
 	}
 
+	if c.OIDCToken != "" || c.OIDCTokenFile != "" {
+		oidcCredentials, err := tokenexchange.NewCredentials(c.OIDCTokenExchangeEndpoint, c.OIDCServiceAccountID, c.OIDCToken, c.OIDCTokenFile, c.httpTransport)
+		if err != nil {
+			return nil, err
+		}
+		return oidcCredentials, nil
+	}
+
+	if c.cliProfile != nil && c.cliProfile.HasCredentials() {
+		return c.cliProfile.Credentials()
+	}
+
 	if sa := ycsdk.InstanceServiceAccount(); checkServiceAccountAvailable(c.Context(), sa) {
 		return sa, nil
 	}
 
-	return nil, fmt.Errorf("one of 'token' or 'service_account_key_file' should be specified; if you are inside compute instance, you can attach service account to it in order to authenticate via instance service account")
+	return nil, fmt.Errorf("one of 'token', 'service_account_key_file', 'oidc_token', 'oidc_token_file' or 'yc_profile' should be specified; if you are inside compute instance, you can attach service account to it in order to authenticate via instance service account")
 }
 
 func iamKeyFromJSONContent(content string) (*iamkey.Key, error) {
@@ -218,27 +346,6 @@ func iamKeyFromJSONContent(content string) (*iamkey.Key, error) {
 	return key, nil
 }
 
-func backoffExponentialWithJitter(base time.Duration, cap time.Duration) retry.BackoffFunc {
-	return func(attempt int) time.Duration {
-		// First call of BackoffFunc would be with attempt arq equal 0
-		log.Printf("[DEBUG] API call retry attempt %d", attempt+1)
-
-		to := getExponentialTimeout(attempt, base)
-		// Using float types here, because exponential time can be really big, and converting it to time.Duration may
-		// result in undefined behaviour. Its safe conversion, when we have compared it to our 'cap' value.
-		if to > float64(cap) {
-			to = float64(cap)
-		}
-
-		return time.Duration(to * rand.Float64())
-	}
-}
-
-func getExponentialTimeout(attempt int, base time.Duration) float64 {
-	mult := math.Pow(2, float64(attempt))
-	return float64(base) * mult
-}
-
 func checkServiceAccountAvailable(ctx context.Context, sa ycsdk.NonExchangeableCredentials) bool {
 	dialer := net.Dialer{Timeout: 50 * time.Millisecond}
 	conn, err := dialer.Dial("tcp", net.JoinHostPort(ycsdk.InstanceMetadataAddr, "80"))
@@ -1,19 +1,31 @@
 package yandex
 
 import (
+	"crypto/tls"
+	"encoding/pem"
 	"io/ioutil"
 	"net"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/endpoint"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/vpc/v1"
 
 	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/endpoints"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tokenexchange"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/transport"
 )
 
 const testConfigToken = "some_special_secured_token"
@@ -59,6 +71,25 @@ func TestConfigInitByServiceAccountKey(t *testing.T) {
 	}
 }
 
+func TestConfigCredentialsOIDC(t *testing.T) {
+	config := Config{
+		Endpoint:                  testConfigEndpoint,
+		FolderID:                  testConfigFolder,
+		OIDCToken:                 "some.oidc.jwt",
+		OIDCServiceAccountID:      "service-account-id",
+		OIDCTokenExchangeEndpoint: "https://localhost/oauth/token",
+	}
+
+	credentials, err := config.credentials()
+	require.NoError(t, err)
+	require.IsType(t, &tokenexchange.Credentials{}, credentials)
+	assert.Equal(t, "service-account-id", credentials.(*tokenexchange.Credentials).ServiceAccountID)
+
+	config.OIDCServiceAccountID = ""
+	_, err = config.credentials()
+	assert.Error(t, err)
+}
+
 func TestConfigUserAgent(t *testing.T) {
 	// make mock grpc server with ApiEndpoint service
 	grpcServer := grpc.NewServer()
@@ -94,6 +125,77 @@ func TestConfigUserAgent(t *testing.T) {
 	assert.Contains(t, mockServerImpl.userAgent, "Terraform/")
 }
 
+func TestConfigImpersonateServiceAccount(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	l := localListener(t)
+
+	iamServer := &impersonationMockServerIAM{}
+	iam.RegisterIamTokenServiceServer(grpcServer, iamServer)
+	endpoint.RegisterApiEndpointServiceServer(grpcServer, &impersonationMockServerAPIEndpoint{addr: l.Addr().String()})
+
+	go func() { _ = grpcServer.Serve(l) }()
+	defer grpcServer.Stop()
+
+	config := Config{
+		Endpoint:                    l.Addr().String(),
+		FolderID:                    testConfigFolder,
+		Token:                       "t1.base.token",
+		ImpersonateServiceAccountID: "impersonated-sa-id",
+		Insecure:                    true,
+		Plaintext:                   true,
+	}
+
+	err := config.initAndValidate(context.Background(), testTerraformVersion, false)
+	require.NoError(t, err)
+
+	token, err := config.createIAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "impersonated-token", token.GetIamToken())
+
+	// regular API calls are made with impersonated token as well, IAM token itself is requested with base credentials
+	_, err = config.sdk.IAM().IamToken().CreateForServiceAccount(context.Background(), &iam.CreateIamTokenForServiceAccountRequest{
+		ServiceAccountId: "another-sa-id",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"impersonated-sa-id", "another-sa-id"}, iamServer.serviceAccountIDs)
+	assert.Equal(t, []string{"Bearer t1.base.token", "Bearer t1.base.token"}, iamServer.authorization)
+}
+
+type impersonationMockServerIAM struct {
+	iam.UnimplementedIamTokenServiceServer
+	serviceAccountIDs []string
+	authorization     []string
+}
+
+func (s *impersonationMockServerIAM) CreateForServiceAccount(ctx context.Context, r *iam.CreateIamTokenForServiceAccountRequest) (*iam.CreateIamTokenResponse, error) {
+	reqMd, _ := metadata.FromIncomingContext(ctx)
+	s.serviceAccountIDs = append(s.serviceAccountIDs, r.GetServiceAccountId())
+	s.authorization = append(s.authorization, reqMd.Get("authorization")...)
+	return &iam.CreateIamTokenResponse{
+		IamToken:  "impersonated-token",
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	}, nil
+}
+
+type impersonationMockServerAPIEndpoint struct {
+	addr string
+}
+
+func (s *impersonationMockServerAPIEndpoint) Get(context.Context, *endpoint.GetApiEndpointRequest) (*endpoint.ApiEndpoint, error) {
+	return &endpoint.ApiEndpoint{}, nil
+}
+
+func (s *impersonationMockServerAPIEndpoint) List(context.Context, *endpoint.ListApiEndpointsRequest) (*endpoint.ListApiEndpointsResponse, error) {
+	return &endpoint.ListApiEndpointsResponse{
+		Endpoints: []*endpoint.ApiEndpoint{
+			{
+				Id:      "iam",
+				Address: s.addr,
+			},
+		},
+	}, nil
+}
+
 type userAgentMockServerAPIEndpoint struct {
 	userAgent string
 	addr      string
@@ -120,6 +222,89 @@ func (s *userAgentMockServerAPIEndpoint) List(ctx context.Context, r *endpoint.L
 	}, nil
 }
 
+func TestConfigEndpointOverrides(t *testing.T) {
+	// API endpoint knows only about unreachable compute and vpc services.
+	discoveryServer := grpc.NewServer()
+	endpoint.RegisterApiEndpointServiceServer(discoveryServer, &endpointsMockServerAPIEndpoint{endpoints: []*endpoint.ApiEndpoint{
+		{Id: "compute", Address: "compute.invalid:443"},
+		{Id: "vpc", Address: "vpc.invalid:443"},
+	}})
+	discoveryListener := localListener(t)
+	go func() { _ = discoveryServer.Serve(discoveryListener) }()
+	defer discoveryServer.Stop()
+
+	// Compute is overridden with plaintext fake server.
+	computeServer := grpc.NewServer()
+	compute.RegisterInstanceServiceServer(computeServer, &endpointsMockServerInstance{})
+	computeListener := localListener(t)
+	go func() { _ = computeServer.Serve(computeListener) }()
+	defer computeServer.Stop()
+
+	// VPC is overridden with TLS fake server, having certificate trusted via ca_content.
+	tlsServer := httptest.NewUnstartedServer(nil)
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+	vpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: tlsServer.TLS.Certificates})))
+	vpc.RegisterNetworkServiceServer(vpcServer, &endpointsMockServerNetwork{})
+	vpcListener := localListener(t)
+	go func() { _ = vpcServer.Serve(vpcListener) }()
+	defer vpcServer.Stop()
+
+	overrides, err := endpoints.Build(map[string]string{
+		"compute": "grpc://" + computeListener.Addr().String(),
+		"vpc":     "grpcs://" + vpcListener.Addr().String(),
+	})
+	require.NoError(t, err)
+
+	config := Config{
+		Endpoint:  discoveryListener.Addr().String(),
+		Endpoints: overrides,
+		FolderID:  testConfigFolder,
+		Token:     "t1.base.token",
+		Plaintext: true,
+		Transport: transport.Settings{
+			CAContent: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})),
+		},
+	}
+	require.NoError(t, config.initAndValidate(context.Background(), testTerraformVersion, false))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	instance, err := config.sdk.Compute().Instance().Get(ctx, &compute.GetInstanceRequest{InstanceId: "instance-id"})
+	require.NoError(t, err)
+	assert.Equal(t, "fake-instance-id", instance.GetName())
+
+	network, err := config.sdk.VPC().Network().Get(ctx, &vpc.GetNetworkRequest{NetworkId: "network-id"})
+	require.NoError(t, err)
+	assert.Equal(t, "fake-network-id", network.GetName())
+}
+
+type endpointsMockServerAPIEndpoint struct {
+	endpoint.UnimplementedApiEndpointServiceServer
+	endpoints []*endpoint.ApiEndpoint
+}
+
+func (s *endpointsMockServerAPIEndpoint) List(context.Context, *endpoint.ListApiEndpointsRequest) (*endpoint.ListApiEndpointsResponse, error) {
+	return &endpoint.ListApiEndpointsResponse{Endpoints: s.endpoints}, nil
+}
+
+type endpointsMockServerInstance struct {
+	compute.UnimplementedInstanceServiceServer
+}
+
+func (s *endpointsMockServerInstance) Get(_ context.Context, r *compute.GetInstanceRequest) (*compute.Instance, error) {
+	return &compute.Instance{Id: r.GetInstanceId(), Name: "fake-" + r.GetInstanceId()}, nil
+}
+
+type endpointsMockServerNetwork struct {
+	vpc.UnimplementedNetworkServiceServer
+}
+
+func (s *endpointsMockServerNetwork) Get(_ context.Context, r *vpc.GetNetworkRequest) (*vpc.Network, error) {
+	return &vpc.Network{Id: r.GetNetworkId(), Name: "fake-" + r.GetNetworkId()}, nil
+}
+
 func localListener(t *testing.T) net.Listener {
 	l, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
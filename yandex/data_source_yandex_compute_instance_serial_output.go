@@ -0,0 +1,108 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+)
+
+const (
+	yandexComputeInstanceSerialOutputPollInterval   = 10 * time.Second
+	yandexComputeInstanceSerialOutputDefaultTimeout = 10 * time.Minute
+)
+
+func dataSourceYandexComputeInstanceSerialOutput() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceYandexComputeInstanceSerialOutputRead,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 4),
+			},
+
+			"output": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceYandexComputeInstanceSerialOutputRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	ctx := config.Context()
+
+	instanceID := d.Get("instance_id").(string)
+	output, err := getComputeInstanceSerialPortOutput(ctx, config, instanceID, int64(d.Get("port").(int)))
+	if err != nil {
+		return fmt.Errorf("Error getting serial output of instance %q: %s", instanceID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", instanceID, d.Get("port").(int)))
+	return d.Set("output", output)
+}
+
+func getComputeInstanceSerialPortOutput(ctx context.Context, config *Config, instanceID string, port int64) (string, error) {
+	resp, err := config.sdk.Compute().Instance().GetSerialPortOutput(ctx, &compute.GetInstanceSerialPortOutputRequest{
+		InstanceId: instanceID,
+		Port:       port,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetContents(), nil
+}
+
+// waitComputeInstanceSerialOutput polls output of the first serial port of the instance until it matches the regexp,
+// e.g. until cloud-init prints a readiness marker, or until the deadline of the context.
+func waitComputeInstanceSerialOutput(ctx context.Context, config *Config, instanceID string, re *regexp.Regexp) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, yandexComputeInstanceSerialOutputDefaultTimeout)
+		defer cancel()
+	}
+
+	log.Printf("[DEBUG] Waiting for serial output of instance %q to match %q", instanceID, re)
+	var output string
+	for {
+		current, err := getComputeInstanceSerialPortOutput(ctx, config, instanceID, 1)
+		if err != nil {
+			return fmt.Errorf("Error getting serial output of instance %q: %s, last serial output lines:\n%s",
+				instanceID, err, lastLines(output, 20))
+		}
+		output = current
+		if re.MatchString(output) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Error waiting for serial output of instance %q to match %q: %s, last serial output lines:\n%s",
+				instanceID, re, ctx.Err(), lastLines(output, 20))
+		case <-time.After(yandexComputeInstanceSerialOutputPollInterval):
+		}
+	}
+}
+
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,134 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAccDataSourceComputeInstanceSerialOutput_basic(t *testing.T) {
+	t.Parallel()
+
+	instanceName := fmt.Sprintf("data-instance-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceComputeInstanceSerialOutputConfig(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.yandex_compute_instance_serial_output.foo", "instance_id",
+						"yandex_compute_instance.foo", "id"),
+					resource.TestMatchResourceAttr("data.yandex_compute_instance_serial_output.foo", "output",
+						regexp.MustCompile("Cloud-init .* finished")),
+				),
+			},
+		},
+	})
+}
+
+func TestLastLines(t *testing.T) {
+	assert.Equal(t, "", lastLines("", 2))
+	assert.Equal(t, "a\nb", lastLines("a\nb\n", 2))
+	assert.Equal(t, "b\nc", lastLines("a\nb\nc\n", 2))
+}
+
+func TestWaitComputeInstanceSerialOutput(t *testing.T) {
+	config := newComputeTestConfig(t, func(s *grpc.Server) {
+		compute.RegisterInstanceServiceServer(s, &serialOutputMockServerInstance{output: "booting\nCloud-init v. 23.1 running\n"})
+	})
+
+	err := waitComputeInstanceSerialOutput(context.Background(), config, "instance-id", regexp.MustCompile("Cloud-init .* running"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	err = waitComputeInstanceSerialOutput(ctx, config, "instance-id", regexp.MustCompile("Cloud-init .* finished"))
+	require.Error(t, err)
+	assert.Less(t, time.Since(started), yandexComputeInstanceSerialOutputPollInterval, "wait is not stopped by the context")
+	assert.Contains(t, err.Error(), "Cloud-init v. 23.1 running")
+}
+
+func TestDataSourceComputeInstanceSerialOutputNotFound(t *testing.T) {
+	config := newComputeTestConfig(t, func(s *grpc.Server) {
+		compute.RegisterInstanceServiceServer(s, &serialOutputMockServerInstance{})
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceYandexComputeInstanceSerialOutput().Schema, map[string]interface{}{
+		"instance_id": "missing-instance-id",
+	})
+	err := dataSourceYandexComputeInstanceSerialOutputRead(d, config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing-instance-id")
+}
+
+type serialOutputMockServerInstance struct {
+	compute.UnimplementedInstanceServiceServer
+	output string
+}
+
+func (s *serialOutputMockServerInstance) GetSerialPortOutput(_ context.Context, r *compute.GetInstanceSerialPortOutputRequest) (*compute.GetInstanceSerialPortOutputResponse, error) {
+	if s.output == "" {
+		return nil, status.Errorf(codes.NotFound, "instance %s not found", r.GetInstanceId())
+	}
+	return &compute.GetInstanceSerialPortOutputResponse{Contents: s.output}, nil
+}
+
+func testAccDataSourceComputeInstanceSerialOutputConfig(instanceName string) string {
+	return fmt.Sprintf(`
+data "yandex_compute_image" "ubuntu" {
+  family = "ubuntu-2204-lts"
+}
+
+resource "yandex_compute_instance" "foo" {
+  name        = "%s"
+  platform_id = "standard-v2"
+  zone        = "ru-central1-a"
+
+  resources {
+    cores  = 2
+    memory = 2
+  }
+
+  boot_disk {
+    initialize_params {
+      size     = 10
+      image_id = data.yandex_compute_image.ubuntu.id
+    }
+  }
+
+  network_interface {
+    subnet_id = yandex_vpc_subnet.inst-test-subnet.id
+  }
+
+  wait_for_serial_output_regex = "Cloud-init .* finished"
+}
+
+data "yandex_compute_instance_serial_output" "foo" {
+  instance_id = yandex_compute_instance.foo.id
+}
+
+resource "yandex_vpc_network" "inst-test-network" {}
+
+resource "yandex_vpc_subnet" "inst-test-subnet" {
+  zone           = "ru-central1-a"
+  network_id     = yandex_vpc_network.inst-test-network.id
+  v4_cidr_blocks = ["192.168.0.0/24"]
+}
+`, instanceName)
+}
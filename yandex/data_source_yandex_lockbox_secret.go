@@ -108,6 +108,54 @@ func dataSourceYandexLockboxSecret() *schema.Resource {
 				Computed: true,
 			},
 
+			"password_payload_specification": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"password_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"length": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"include_uppercase": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"include_lowercase": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"include_digits": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"include_punctuation": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"included_punctuation": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"excluded_punctuation": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Computed: true,
+			},
+
 			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/lockbox/v1"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -39,6 +40,11 @@ func dataSourceYandexLockboxSecretVersion() *schema.Resource {
 							Computed:  true,
 							Sensitive: true,
 						},
+
+						"generated": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
 					},
 				},
 				Computed: true,
@@ -71,7 +77,15 @@ func dataSourceYandexLockboxSecretVersionRead(ctx context.Context, d *schema.Res
 
 	d.SetId(payload.VersionId)
 
-	entries, err := flattenLockboxSecretVersionEntriesSlice(payload.GetEntries())
+	version, err := findLockboxVersion(ctx, config, req.SecretId, payload.VersionId)
+	if isStatusWithCode(err, codes.PermissionDenied) {
+		// Payload may be readable without permission to list versions, generated entries are just not marked then
+		log.Printf("[WARN] could not list versions of secret %v to find generated entries: %s", req.SecretId, err)
+	} else if err != nil {
+		return diag.Errorf("could not get version %v of secret %v: %s", payload.VersionId, req.SecretId, err)
+	}
+
+	entries, err := flattenLockboxSecretVersionEntriesSlice(payload.GetEntries(), version.GetPasswordPayloadSpecification().GetPasswordKey())
 	if err != nil {
 		return diag.FromErr(err)
 	}
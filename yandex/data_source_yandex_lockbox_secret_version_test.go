@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/lockbox/v1"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -47,6 +48,29 @@ func TestAccDataSourceLockboxVersion_basic(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceLockboxVersion_generatedPassword(t *testing.T) {
+	secretName := "a" + acctest.RandString(10)
+	generatedData := "data.yandex_lockbox_secret_version.generated_version"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckYandexLockboxSecretAllDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(GENERATED_VERSION_RESOURCE_AND_DATA, secretName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceLockboxSecretVersionExists(generatedData),
+					resource.TestCheckResourceAttr("yandex_lockbox_secret.generated_secret", "password_payload_specification.0.password_key", "password"),
+					resource.TestCheckResourceAttr(generatedData, "entries.#", "1"),
+					resource.TestCheckResourceAttr(generatedData, "entries.0.key", "password"),
+					resource.TestCheckResourceAttr(generatedData, "entries.0.generated", "true"),
+					resource.TestMatchResourceAttr(generatedData, "entries.0.text_value", regexp.MustCompile("^[a-zA-Z0-9]{24}$")),
+				),
+			},
+		},
+	})
+}
+
 func testAccLockboxSecretVersionKeyResource(name string, secret_version_config string) string {
 	return fmt.Sprintf(`
 resource "yandex_lockbox_secret" "basic_secret" {
@@ -92,6 +116,26 @@ data "yandex_lockbox_secret_version" "basic_version2" {
 }
 `
 
+const GENERATED_VERSION_RESOURCE_AND_DATA = `
+resource "yandex_lockbox_secret" "generated_secret" {
+  name = "%v"
+  password_payload_specification {
+    password_key        = "password"
+    length              = 24
+    include_punctuation = false
+  }
+}
+
+resource "yandex_lockbox_secret_version" "generated_version" {
+  secret_id = yandex_lockbox_secret.generated_secret.id
+}
+
+data "yandex_lockbox_secret_version" "generated_version" {
+  secret_id  = yandex_lockbox_secret.generated_secret.id
+  version_id = yandex_lockbox_secret_version.generated_version.id
+}
+`
+
 func testAccDataSourceLockboxSecretVersionExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		ds, ok := s.RootModule().Resources[name]
@@ -10,6 +10,10 @@ func dataSourceYandexMDBKafkaUser() *schema.Resource {
 	dataSource.Schema["cluster_id"].Required = true
 	dataSource.Schema["name"].Computed = false
 	dataSource.Schema["name"].Required = true
+	// Write-only password is meaningful only for the resource.
+	dataSource.Schema["password"].ExactlyOneOf = nil
+	delete(dataSource.Schema, "password_wo")
+	delete(dataSource.Schema, "password_wo_version")
 	// TODO: SA1019: dataSource.Read is deprecated: Use ReadContext or ReadWithoutTimeout instead. This implementation does not support request cancellation initiated by Terraform, such as a system or practitioner sending SIGINT (Ctrl-c). This implementation also does not support warning diagnostics. (staticcheck)
 	dataSource.Read = dataSourceYandexMDBKafkaUserRead
 	return dataSource
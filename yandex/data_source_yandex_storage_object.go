@@ -0,0 +1,258 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Body of the object is read into the state, so it is limited to small objects only.
+const storageObjectMaxBodySize = 1024 * 1024
+
+func dataSourceYandexStorageObject() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceYandexStorageObjectRead,
+
+		SchemaVersion: 0,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"version_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"access_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"secret_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+
+			"read_body": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"body": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"content_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"content_length": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"content_encoding": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cache_control": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"last_modified": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"storage_class": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"metadata": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"object_lock_legal_hold_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"object_lock_mode": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"object_lock_retain_until_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceYandexStorageObjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	s3conn, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+
+	input := &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+	if v, ok := d.GetOk("version_id"); ok {
+		input.VersionId = aws.String(v.(string))
+	}
+
+	resp, err := s3conn.HeadObjectWithContext(ctx, input)
+	if err != nil {
+		if awsErr, ok := err.(awserr.RequestFailure); ok && awsErr.StatusCode() == 404 {
+			return diag.Errorf("storage object %q not found in bucket %q", key, bucket)
+		}
+		return diag.Errorf("error reading storage object %q in bucket %q: %s", key, bucket, err)
+	}
+	log.Printf("[DEBUG] Reading storage object meta: %s", resp)
+
+	d.SetId(bucket + "/" + key)
+
+	d.Set("version_id", aws.StringValue(resp.VersionId))
+	d.Set("content_type", aws.StringValue(resp.ContentType))
+	d.Set("content_length", int(aws.Int64Value(resp.ContentLength)))
+	d.Set("content_encoding", aws.StringValue(resp.ContentEncoding))
+	d.Set("cache_control", aws.StringValue(resp.CacheControl))
+	d.Set("etag", strings.Trim(aws.StringValue(resp.ETag), `"`))
+	d.Set("storage_class", aws.StringValue(resp.StorageClass))
+	d.Set("object_lock_legal_hold_status", aws.StringValue(resp.ObjectLockLegalHoldStatus))
+	d.Set("object_lock_mode", aws.StringValue(resp.ObjectLockMode))
+	if resp.LastModified != nil {
+		d.Set("last_modified", resp.LastModified.Format(time.RFC3339))
+	}
+	if resp.ObjectLockRetainUntilDate != nil {
+		d.Set("object_lock_retain_until_date", resp.ObjectLockRetainUntilDate.Format(time.RFC3339))
+	}
+	if err := d.Set("metadata", aws.StringValueMap(resp.Metadata)); err != nil {
+		return diag.Errorf("error setting metadata: %s", err)
+	}
+
+	tagsResponse, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
+		return s3conn.GetObjectTaggingWithContext(ctx, &s3.GetObjectTaggingInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(key),
+			VersionId: resp.VersionId,
+		})
+	})
+	if err != nil {
+		return diag.Errorf("error getting tags of storage object %q: %s", key, err)
+	}
+	if err := d.Set("tags", storageBucketTaggingNormalize(tagsResponse.(*s3.GetObjectTaggingOutput).TagSet)); err != nil {
+		return diag.Errorf("error setting tags: %s", err)
+	}
+
+	if !d.Get("read_body").(bool) {
+		return nil
+	}
+
+	contentType := aws.StringValue(resp.ContentType)
+	if !isStorageObjectContentTypeText(contentType) {
+		return diag.Errorf("body of storage object %q can't be read: content type %q is not a text one", key, contentType)
+	}
+	if size := aws.Int64Value(resp.ContentLength); size > storageObjectMaxBodySize {
+		return diag.Errorf("body of storage object %q can't be read: size %d is greater than %d bytes", key, size, storageObjectMaxBodySize)
+	}
+
+	body, err := readStorageObjectBody(ctx, s3conn, bucket, key, resp.VersionId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("body", body)
+
+	return nil
+}
+
+func readStorageObjectBody(ctx context.Context, s3conn *s3.S3, bucket, key string, versionID *string) (string, error) {
+	out, err := s3conn.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: versionID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error getting storage object %q in bucket %q: %s", key, bucket, err)
+	}
+	defer out.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(out.Body, storageObjectMaxBodySize))
+	if err != nil {
+		return "", fmt.Errorf("error reading body of storage object %q in bucket %q: %s", key, bucket, err)
+	}
+	return string(body), nil
+}
+
+// isStorageObjectContentTypeText reports whether the object of the content type can be stored as a string.
+func isStorageObjectContentTypeText(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case strings.HasSuffix(mediaType, "+json"), strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+
+	switch mediaType {
+	case "application/json",
+		"application/xml",
+		"application/javascript",
+		"application/x-yaml",
+		"application/yaml",
+		"application/x-sh",
+		"application/x-www-form-urlencoded":
+		return true
+	}
+	return false
+}
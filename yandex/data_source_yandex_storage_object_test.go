@@ -0,0 +1,82 @@
+package yandex
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestIsStorageObjectContentTypeText(t *testing.T) {
+	for contentType, expected := range map[string]bool{
+		"text/plain":               true,
+		"text/html; charset=utf-8": true,
+		"application/json":         true,
+		"application/ld+json":      true,
+		"image/svg+xml":            true,
+		"application/octet-stream": false,
+		"image/png":                false,
+		"":                         false,
+		"invalid content type; a=": false,
+	} {
+		if actual := isStorageObjectContentTypeText(contentType); actual != expected {
+			t.Errorf("unexpected result for %q: %t", contentType, actual)
+		}
+	}
+}
+
+func TestAccDataSourceStorageObject_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+	dataSourceName := "data.yandex_storage_object.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStorageObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceStorageObjectConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "key", "test-key"),
+					resource.TestCheckResourceAttr(dataSourceName, "content_type", "text/plain"),
+					resource.TestCheckResourceAttr(dataSourceName, "content_length", "12"),
+					resource.TestCheckResourceAttr(dataSourceName, "body", "some content"),
+					resource.TestCheckResourceAttr(dataSourceName, "tags.test", "value"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "etag"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "last_modified"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceStorageObjectConfig(randInt int) string {
+	bucketConfig := newBucketConfigBuilder(randInt).asEditor().render()
+
+	return bucketConfig + fmt.Sprintf(`
+resource "yandex_storage_object" "test" {
+	bucket = yandex_storage_bucket.test.bucket
+
+	access_key = yandex_iam_service_account_static_access_key.sa-key.access_key
+	secret_key = yandex_iam_service_account_static_access_key.sa-key.secret_key
+
+	key          = "test-key"
+	content      = "%s"
+	content_type = "text/plain"
+	tags = {
+		test = "value"
+	}
+}
+
+data "yandex_storage_object" "test" {
+	bucket = yandex_storage_object.test.bucket
+	key    = yandex_storage_object.test.key
+
+	access_key = yandex_iam_service_account_static_access_key.sa-key.access_key
+	secret_key = yandex_iam_service_account_static_access_key.sa-key.secret_key
+
+	read_body = true
+}
+`, "some content")
+}
@@ -0,0 +1,177 @@
+package yandex
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const storageObjectsDefaultMaxKeys = 1000
+
+func dataSourceYandexStorageObjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceYandexStorageObjectsRead,
+
+		SchemaVersion: 0,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"delimiter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"max_keys": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      storageObjectsDefaultMaxKeys,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"access_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"secret_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"common_prefixes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"objects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"etag": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_modified": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"storage_class": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceYandexStorageObjectsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	s3conn, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	prefix := d.Get("prefix").(string)
+	maxKeys := d.Get("max_keys").(int)
+
+	input := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		MaxKeys: aws.Int64(int64(maxKeys)),
+	}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	if v, ok := d.GetOk("delimiter"); ok {
+		input.Delimiter = aws.String(v.(string))
+	}
+
+	var (
+		keys           []string
+		commonPrefixes []string
+		objects        []map[string]interface{}
+	)
+	// Both keys and common prefixes count against max_keys, the same as in a single ListObjectsV2 request
+	err = s3conn.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, p := range page.CommonPrefixes {
+			if len(keys)+len(commonPrefixes) >= maxKeys {
+				return false
+			}
+			commonPrefixes = append(commonPrefixes, aws.StringValue(p.Prefix))
+		}
+		for _, object := range page.Contents {
+			if len(keys)+len(commonPrefixes) >= maxKeys {
+				return false
+			}
+			keys = append(keys, aws.StringValue(object.Key))
+			objects = append(objects, flattenStorageObjectSummary(object))
+		}
+		return len(keys)+len(commonPrefixes) < maxKeys
+	})
+	if err != nil {
+		return diag.Errorf("error listing objects in bucket %q: %s", bucket, err)
+	}
+	log.Printf("[DEBUG] Listed %d objects and %d common prefixes in bucket %q", len(keys), len(commonPrefixes), bucket)
+
+	d.SetId(bucket + "/" + prefix)
+
+	if err := d.Set("keys", keys); err != nil {
+		return diag.Errorf("error setting keys: %s", err)
+	}
+	if err := d.Set("common_prefixes", commonPrefixes); err != nil {
+		return diag.Errorf("error setting common_prefixes: %s", err)
+	}
+	if err := d.Set("objects", objects); err != nil {
+		return diag.Errorf("error setting objects: %s", err)
+	}
+
+	return nil
+}
+
+func flattenStorageObjectSummary(object *s3.Object) map[string]interface{} {
+	m := map[string]interface{}{
+		"key":           aws.StringValue(object.Key),
+		"size":          int(aws.Int64Value(object.Size)),
+		"etag":          strings.Trim(aws.StringValue(object.ETag), `"`),
+		"storage_class": aws.StringValue(object.StorageClass),
+	}
+	if object.LastModified != nil {
+		m["last_modified"] = object.LastModified.Format(time.RFC3339)
+	}
+	return m
+}
@@ -0,0 +1,79 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceStorageObjects_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+	dataSourceName := "data.yandex_storage_objects.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStorageObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceStorageObjectsConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "keys.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "keys.0", "site/index.html"),
+					resource.TestCheckResourceAttr(dataSourceName, "common_prefixes.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "common_prefixes.0", "site/css/"),
+					resource.TestCheckResourceAttr(dataSourceName, "objects.0.size", "4"),
+					resource.TestCheckResourceAttr("data.yandex_storage_objects.limited", "keys.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceStorageObjectsConfig(randInt int) string {
+	bucketConfig := newBucketConfigBuilder(randInt).asEditor().render()
+
+	return bucketConfig + `
+resource "yandex_storage_object" "index" {
+	bucket = yandex_storage_bucket.test.bucket
+
+	access_key = yandex_iam_service_account_static_access_key.sa-key.access_key
+	secret_key = yandex_iam_service_account_static_access_key.sa-key.secret_key
+
+	key     = "site/index.html"
+	content = "html"
+}
+
+resource "yandex_storage_object" "style" {
+	bucket = yandex_storage_bucket.test.bucket
+
+	access_key = yandex_iam_service_account_static_access_key.sa-key.access_key
+	secret_key = yandex_iam_service_account_static_access_key.sa-key.secret_key
+
+	key     = "site/css/style.css"
+	content = "body {}"
+}
+
+data "yandex_storage_objects" "test" {
+	bucket    = yandex_storage_bucket.test.bucket
+	prefix    = "site/"
+	delimiter = "/"
+
+	access_key = yandex_iam_service_account_static_access_key.sa-key.access_key
+	secret_key = yandex_iam_service_account_static_access_key.sa-key.secret_key
+
+	depends_on = [yandex_storage_object.index, yandex_storage_object.style]
+}
+
+data "yandex_storage_objects" "limited" {
+	bucket   = yandex_storage_bucket.test.bucket
+	max_keys = 1
+
+	access_key = yandex_iam_service_account_static_access_key.sa-key.access_key
+	secret_key = yandex_iam_service_account_static_access_key.sa-key.secret_key
+
+	depends_on = [yandex_storage_object.index, yandex_storage_object.style]
+}
+`
+}
@@ -792,6 +792,10 @@ func expandInstanceGroupInstanceTemplate(d *schema.ResourceData, prefix string,
 	if err != nil {
 		return nil, fmt.Errorf("Error expanding metadata while creating instance group: %s", err)
 	}
+	metadata, err = expandMetadataWithCloudInit(metadata, d.Get(prefix+".cloud_init"))
+	if err != nil {
+		return nil, fmt.Errorf("Error expanding cloud_init while creating instance group: %s", err)
+	}
 
 	networkSettings, err := expandInstanceGroupNetworkSettings(d.Get(prefix + ".network_settings.0.type"))
 	if err != nil {
@@ -7,8 +7,10 @@ import (
 	"os/exec"
 	"regexp"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/lockbox/v1"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 type lockboxEntryCheck struct {
@@ -36,6 +38,45 @@ func flattenLockboxVersion(v *lockbox.Version) ([]map[string]interface{}, error)
 	return []map[string]interface{}{m}, nil
 }
 
+func expandLockboxPasswordPayloadSpecification(d *schema.ResourceData) *lockbox.PasswordPayloadSpecification {
+	if _, ok := d.GetOk("password_payload_specification.0"); !ok {
+		return nil
+	}
+
+	return &lockbox.PasswordPayloadSpecification{
+		PasswordKey:         d.Get("password_payload_specification.0.password_key").(string),
+		Length:              int64(d.Get("password_payload_specification.0.length").(int)),
+		IncludeUppercase:    wrapperspb.Bool(d.Get("password_payload_specification.0.include_uppercase").(bool)),
+		IncludeLowercase:    wrapperspb.Bool(d.Get("password_payload_specification.0.include_lowercase").(bool)),
+		IncludeDigits:       wrapperspb.Bool(d.Get("password_payload_specification.0.include_digits").(bool)),
+		IncludePunctuation:  wrapperspb.Bool(d.Get("password_payload_specification.0.include_punctuation").(bool)),
+		IncludedPunctuation: d.Get("password_payload_specification.0.included_punctuation").(string),
+		ExcludedPunctuation: d.Get("password_payload_specification.0.excluded_punctuation").(string),
+	}
+}
+
+func flattenLockboxPasswordPayloadSpecification(spec *lockbox.PasswordPayloadSpecification) []map[string]interface{} {
+	if spec == nil {
+		return nil
+	}
+
+	// Unset flags are true by default
+	boolValue := func(v *wrapperspb.BoolValue) bool {
+		return v == nil || v.GetValue()
+	}
+
+	return []map[string]interface{}{{
+		"password_key":         spec.GetPasswordKey(),
+		"length":               int(spec.GetLength()),
+		"include_uppercase":    boolValue(spec.GetIncludeUppercase()),
+		"include_lowercase":    boolValue(spec.GetIncludeLowercase()),
+		"include_digits":       boolValue(spec.GetIncludeDigits()),
+		"include_punctuation":  boolValue(spec.GetIncludePunctuation()),
+		"included_punctuation": spec.GetIncludedPunctuation(),
+		"excluded_punctuation": spec.GetExcludedPunctuation(),
+	}}
+}
+
 func expandLockboxSecretVersionEntriesSlice(ctx context.Context, d *schema.ResourceData) ([]*lockbox.PayloadEntryChange, error) {
 	count := d.Get("entries.#").(int)
 	slice := make([]*lockbox.PayloadEntryChange, count)
@@ -63,10 +104,21 @@ func expandLockboxSecretVersionEntries(ctx context.Context, d *schema.ResourceDa
 		val.SetTextValue(v.(string))
 	}
 
+	textValueWO, err := getWriteOnlyString(d, cty.GetAttrPath("entries").IndexInt(indexes[0].(int)).GetAttr("text_value_wo"))
+	if err != nil {
+		return nil, err
+	}
+	if textValueWO != "" {
+		if val.GetTextValue() != "" {
+			return nil, fmt.Errorf("key %v has both text_value and text_value_wo, but only one of those must be set", val.GetKey())
+		}
+		val.SetTextValue(textValueWO)
+	}
+
 	if execRaw, ok := d.GetOk(fmt.Sprintf("entries.%d.command.0", indexes...)); ok {
 		if val.GetTextValue() != "" {
 			// We must validate manually - https://github.com/hashicorp/terraform-plugin-sdk/issues/470
-			return nil, fmt.Errorf("key %v has both text_value and command, but only one of those must be set", val.GetKey())
+			return nil, fmt.Errorf("key %v has both text value and command, but only one of those must be set", val.GetKey())
 		}
 		execMap := execRaw.(map[string]interface{})
 		result, err := resolveCommand(ctx, execMap)
@@ -111,19 +163,34 @@ func runCommand(ctx context.Context, path string, envMap map[string]string, args
 	return outBuf.String(), nil
 }
 
-func flattenLockboxSecretVersionEntriesSlice(vs []*lockbox.Payload_Entry) ([]interface{}, error) {
+// flattenLockboxSecretVersionEntriesSlice flattens payload entries, marking the one with generatedKey as generated by Lockbox.
+func flattenLockboxSecretVersionEntriesSlice(vs []*lockbox.Payload_Entry, generatedKey string) ([]interface{}, error) {
 	s := make([]interface{}, 0, len(vs))
 	for _, v := range vs {
-		s = append(s, flattenLockboxSecretVersionEntry(v))
+		s = append(s, flattenLockboxSecretVersionEntry(v, generatedKey))
 	}
 	return s, nil
 }
 
-func flattenLockboxSecretVersionEntry(v *lockbox.Payload_Entry) map[string]interface{} {
+func flattenLockboxSecretVersionEntry(v *lockbox.Payload_Entry, generatedKey string) map[string]interface{} {
 	return map[string]interface{}{
 		"key":        v.Key,
 		"text_value": v.GetTextValue(),
+		"generated":  generatedKey != "" && v.Key == generatedKey,
+	}
+}
+
+// findLockboxVersion returns the version of the secret, or nil if there is no such version.
+func findLockboxVersion(ctx context.Context, config *Config, secretID, versionID string) (*lockbox.Version, error) {
+	it := config.sdk.LockboxSecret().Secret().SecretVersionsIterator(ctx, &lockbox.ListVersionsRequest{
+		SecretId: secretID,
+	})
+	for it.Next() {
+		if it.Value().GetId() == versionID {
+			return it.Value(), nil
+		}
 	}
+	return nil, it.Error()
 }
 
 func testAccOutputToLockbox(secretId, sensitiveAttr, entryKey string) string {
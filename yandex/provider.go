@@ -15,6 +15,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/endpoints"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ratelimit"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/retrypolicy"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tracing"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/transport"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ycprofile"
 	"github.com/yandex-cloud/terraform-provider-yandex/version"
 )
 
@@ -75,6 +81,34 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 				ConflictsWith: []string{"token"},
 				ValidateFunc:  validateSAKey,
 			},
+			"oidc_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   common.Descriptions["oidc_token"],
+				ConflictsWith: []string{"token", "service_account_key_file", "oidc_token_file"},
+			},
+			"oidc_token_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   common.Descriptions["oidc_token_file"],
+				ConflictsWith: []string{"token", "service_account_key_file"},
+			},
+			"oidc_service_account_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: common.Descriptions["oidc_service_account_id"],
+			},
+			"oidc_token_exchange_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: common.Descriptions["oidc_token_exchange_endpoint"],
+			},
+			"impersonate_service_account_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: common.Descriptions["impersonate_service_account_id"],
+			},
 			"storage_endpoint": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -101,11 +135,137 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 				Optional:    true,
 				Description: common.Descriptions["plaintext"],
 			},
+			"endpoints": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: common.Descriptions["endpoints"],
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ca_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   common.Descriptions["ca_file"],
+				ConflictsWith: []string{"ca_content"},
+			},
+			"ca_content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   common.Descriptions["ca_content"],
+				ConflictsWith: []string{"ca_file"},
+			},
+			"http_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: common.Descriptions["http_proxy"],
+			},
+			"no_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: common.Descriptions["no_proxy"],
+			},
 			"max_retries": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Description: common.Descriptions["max_retries"],
 			},
+			"audit_log_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: common.Descriptions["audit_log_file"],
+			},
+			"tracing": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: common.Descriptions["tracing"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"otlp_endpoint": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: common.Descriptions["tracing_otlp_endpoint"],
+						},
+						"insecure": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: common.Descriptions["tracing_insecure"],
+						},
+					},
+				},
+			},
+			"rate_limit": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: common.Descriptions["rate_limit"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"requests_per_second": rateLimitRequestsPerSecondSchema(),
+						"max_in_flight":       rateLimitMaxInFlightSchema(),
+						"service": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: common.Descriptions["rate_limit_service"],
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: common.Descriptions["rate_limit_service_name"],
+									},
+									"requests_per_second": rateLimitRequestsPerSecondSchema(),
+									"max_in_flight":       rateLimitMaxInFlightSchema(),
+								},
+							},
+						},
+					},
+				},
+			},
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: common.Descriptions["retry"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"codes":      retryCodesSchema(),
+						"base_delay": retryDelaySchema("retry_base_delay"),
+						"max_delay":  retryDelaySchema("retry_max_delay"),
+						"operation_poll": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: common.Descriptions["retry_operation_poll"],
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"max_retries": retryMaxRetriesSchema(),
+									"codes":       retryCodesSchema(),
+									"base_delay":  retryDelaySchema("retry_base_delay"),
+									"max_delay":   retryDelaySchema("retry_max_delay"),
+								},
+							},
+						},
+						"service": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: common.Descriptions["retry_service"],
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: common.Descriptions["retry_service_name"],
+									},
+									"max_retries": retryMaxRetriesSchema(),
+									"codes":       retryCodesSchema(),
+									"base_delay":  retryDelaySchema("retry_base_delay"),
+									"max_delay":   retryDelaySchema("retry_max_delay"),
+								},
+							},
+						},
+					},
+				},
+			},
 			"ymq_endpoint": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -122,6 +282,16 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 				Sensitive:   true,
 				Description: common.Descriptions["ymq_secret_key"],
 			},
+			"allow_temporary_access_keys": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: common.Descriptions["allow_temporary_access_keys"],
+			},
+			"storage_bucket_standalone_configurations": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: common.Descriptions["storage_bucket_standalone_configurations"],
+			},
 			"shared_credentials_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -132,6 +302,16 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 				Optional:    true,
 				Description: common.Descriptions["profile"],
 			},
+			"yc_profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: common.Descriptions["yc_profile"],
+			},
+			"yc_config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: common.Descriptions["yc_config_file"],
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -159,6 +339,7 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 			"yandex_compute_image":                                    dataSourceYandexComputeImage(),
 			"yandex_compute_instance":                                 dataSourceYandexComputeInstance(),
 			"yandex_compute_instance_group":                           dataSourceYandexComputeInstanceGroup(),
+			"yandex_compute_instance_serial_output":                   dataSourceYandexComputeInstanceSerialOutput(),
 			"yandex_compute_placement_group":                          dataSourceYandexComputePlacementGroup(),
 			"yandex_compute_snapshot":                                 dataSourceYandexComputeSnapshot(),
 			"yandex_compute_snapshot_schedule":                        dataSourceYandexComputeSnapshotSchedule(),
@@ -211,6 +392,8 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 			"yandex_resourcemanager_cloud":                            dataSourceYandexResourceManagerCloud(),
 			"yandex_resourcemanager_folder":                           dataSourceYandexResourceManagerFolder(),
 			"yandex_serverless_container":                             dataSourceYandexServerlessContainer(),
+			"yandex_storage_object":                                   dataSourceYandexStorageObject(),
+			"yandex_storage_objects":                                  dataSourceYandexStorageObjects(),
 			"yandex_vpc_address":                                      dataSourceYandexVPCAddress(),
 			"yandex_vpc_gateway":                                      dataSourceYandexVPCGateway(),
 			"yandex_vpc_network":                                      dataSourceYandexVPCNetwork(),
@@ -225,126 +408,137 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"yandex_alb_backend_group":                                resourceYandexALBBackendGroup(),
-			"yandex_alb_http_router":                                  resourceYandexALBHTTPRouter(),
-			"yandex_alb_load_balancer":                                resourceYandexALBLoadBalancer(),
-			"yandex_alb_target_group":                                 resourceYandexALBTargetGroup(),
-			"yandex_alb_virtual_host":                                 addPassthroughImport(withALBVirtualHostID(resourceYandexALBVirtualHost())),
-			"yandex_api_gateway":                                      resourceYandexApiGateway(),
-			"yandex_audit_trails_trail":                               resourceYandexAuditTrailsTrail(),
-			"yandex_backup_policy":                                    resourceYandexBackupPolicy(),
-			"yandex_backup_policy_bindings":                           resourceYandexBackupPolicyBindings(),
-			"yandex_container_registry":                               resourceYandexContainerRegistry(),
-			"yandex_container_registry_iam_binding":                   resourceYandexContainerRegistryIAMBinding(),
-			"yandex_container_registry_ip_permission":                 resourceYandexContainerRegistryIPPermission(),
-			"yandex_container_repository":                             resourceYandexContainerRepository(),
-			"yandex_container_repository_iam_binding":                 resourceYandexContainerRepositoryIAMBinding(),
-			"yandex_container_repository_lifecycle_policy":            resourceYandexContainerRepositoryLifecyclePolicy(),
-			"yandex_cdn_origin_group":                                 resourceYandexCDNOriginGroup(),
-			"yandex_cdn_resource":                                     resourceYandexCDNResource(),
-			"yandex_cm_certificate":                                   resourceYandexCMCertificate(),
-			"yandex_compute_disk":                                     resourceYandexComputeDisk(),
-			"yandex_compute_disk_placement_group":                     resourceYandexComputeDiskPlacementGroup(),
-			"yandex_compute_filesystem":                               resourceYandexComputeFilesystem(),
-			"yandex_compute_gpu_cluster":                              resourceYandexComputeGpuCluster(),
-			"yandex_compute_image":                                    resourceYandexComputeImage(),
-			"yandex_compute_instance":                                 resourceYandexComputeInstance(),
-			"yandex_compute_instance_group":                           resourceYandexComputeInstanceGroup(),
-			"yandex_compute_placement_group":                          resourceYandexComputePlacementGroup(),
-			"yandex_compute_snapshot":                                 resourceYandexComputeSnapshot(),
-			"yandex_compute_snapshot_schedule":                        resourceYandexComputeSnapshotSchedule(),
-			"yandex_dataproc_cluster":                                 resourceYandexDataprocCluster(),
-			"yandex_datatransfer_endpoint":                            resourceYandexDatatransferEndpoint(),
-			"yandex_datatransfer_transfer":                            resourceYandexDatatransferTransfer(),
-			"yandex_dns_zone_iam_binding":                             resourceYandexDnsZoneIAMBinding(),
-			"yandex_dns_recordset":                                    resourceYandexDnsRecordSet(),
-			"yandex_dns_zone":                                         resourceYandexDnsZone(),
-			"yandex_function":                                         resourceYandexFunction(),
-			"yandex_function_iam_binding":                             resourceYandexFunctionIAMBinding(),
-			"yandex_function_scaling_policy":                          resourceYandexFunctionScalingPolicy(),
-			"yandex_function_trigger":                                 resourceYandexFunctionTrigger(),
-			"yandex_iam_service_account":                              resourceYandexIAMServiceAccount(),
-			"yandex_iam_service_account_api_key":                      resourceYandexIAMServiceAccountAPIKey(),
-			"yandex_iam_service_account_iam_binding":                  resourceYandexIAMServiceAccountIAMBinding(),
-			"yandex_iam_service_account_iam_member":                   resourceYandexIAMServiceAccountIAMMember(),
-			"yandex_iam_service_account_iam_policy":                   resourceYandexIAMServiceAccountIAMPolicy(),
-			"yandex_iam_service_account_key":                          resourceYandexIAMServiceAccountKey(),
-			"yandex_iam_service_account_static_access_key":            resourceYandexIAMServiceAccountStaticAccessKey(),
-			"yandex_iot_core_broker":                                  resourceYandexIoTCoreBroker(),
-			"yandex_iot_core_device":                                  resourceYandexIoTCoreDevice(),
-			"yandex_iot_core_registry":                                resourceYandexIoTCoreRegistry(),
-			"yandex_kms_secret_ciphertext":                            resourceYandexKMSSecretCiphertext(),
-			"yandex_kms_symmetric_key":                                resourceYandexKMSSymmetricKey(),
-			"yandex_kms_symmetric_key_iam_binding":                    resourceYandexKMSSymmetricKeyIAMBinding(),
-			"yandex_kms_asymmetric_encryption_key":                    resourceYandexKMSAsymmetricEncryptionKey(),
-			"yandex_kms_asymmetric_encryption_key_iam_binding":        resourceYandexKMSAsymmetricEncryptionKeyIAMBinding(),
-			"yandex_kms_asymmetric_signature_key":                     resourceYandexKMSAsymmetricSignatureKey(),
-			"yandex_kms_asymmetric_signature_key_iam_binding":         resourceYandexKMSAsymmetricSignatureKeyIAMBinding(),
-			"yandex_kubernetes_cluster":                               resourceYandexKubernetesCluster(),
-			"yandex_kubernetes_node_group":                            resourceYandexKubernetesNodeGroup(),
-			"yandex_lb_network_load_balancer":                         resourceYandexLBNetworkLoadBalancer(),
-			"yandex_lb_target_group":                                  resourceYandexLBTargetGroup(),
-			"yandex_loadtesting_agent":                                resourceYandexLoadtestingAgent(),
-			"yandex_lockbox_secret":                                   resourceYandexLockboxSecret(),
-			"yandex_lockbox_secret_version":                           resourceYandexLockboxSecretVersion(),
-			"yandex_lockbox_secret_version_hashed":                    resourceYandexLockboxSecretVersionHashed(),
-			"yandex_lockbox_secret_iam_binding":                       resourceYandexLockboxSecretIAMBinding(),
-			"yandex_logging_group":                                    resourceYandexLoggingGroup(),
-			"yandex_mdb_clickhouse_cluster":                           resourceYandexMDBClickHouseCluster(),
-			"yandex_mdb_elasticsearch_cluster":                        resourceYandexMDBElasticsearchCluster(),
-			"yandex_mdb_greenplum_cluster":                            resourceYandexMDBGreenplumCluster(),
-			"yandex_mdb_kafka_cluster":                                resourceYandexMDBKafkaCluster(),
-			"yandex_mdb_kafka_topic":                                  resourceYandexMDBKafkaTopic(),
-			"yandex_mdb_kafka_connector":                              resourceYandexMDBKafkaConnector(),
-			"yandex_mdb_kafka_user":                                   resourceYandexMDBKafkaUser(),
-			"yandex_mdb_mongodb_cluster":                              resourceYandexMDBMongodbCluster(),
-			"yandex_mdb_mysql_cluster":                                resourceYandexMDBMySQLCluster(),
-			"yandex_mdb_mysql_database":                               resourceYandexMDBMySQLDatabase(),
-			"yandex_mdb_mysql_user":                                   resourceYandexMDBMySQLUser(),
-			"yandex_mdb_postgresql_cluster":                           resourceYandexMDBPostgreSQLCluster(),
-			"yandex_mdb_postgresql_database":                          resourceYandexMDBPostgreSQLDatabase(),
-			"yandex_mdb_postgresql_user":                              resourceYandexMDBPostgreSQLUser(),
-			"yandex_mdb_redis_cluster":                                resourceYandexMDBRedisCluster(),
-			"yandex_mdb_sqlserver_cluster":                            resourceYandexMDBSQLServerCluster(),
-			"yandex_message_queue":                                    resourceYandexMessageQueue(),
-			"yandex_monitoring_dashboard":                             resourceYandexMonitoringDashboard(),
-			"yandex_organizationmanager_organization_iam_binding":     resourceYandexOrganizationManagerOrganizationIAMBinding(),
-			"yandex_organizationmanager_organization_iam_member":      resourceYandexOrganizationManagerOrganizationIAMMember(),
-			"yandex_organizationmanager_saml_federation":              resourceYandexOrganizationManagerSamlFederation(),
-			"yandex_organizationmanager_saml_federation_user_account": resourceYandexOrganizationManagerSamlFederationUserAccount(),
-			"yandex_organizationmanager_group":                        resourceYandexOrganizationManagerGroup(),
-			"yandex_organizationmanager_group_iam_member":             resourceYandexOrganizationManagerGroupIAMMember(),
-			"yandex_organizationmanager_group_membership":             resourceYandexOrganizationManagerGroupMembership(),
-			"yandex_organizationmanager_os_login_settings":            resourceYandexOrganizationManagerOsLoginSettings(),
-			"yandex_organizationmanager_user_ssh_key":                 resourceYandexOrganizationManagerUserSshKey(),
-			"yandex_resourcemanager_cloud":                            resourceYandexResourceManagerCloud(),
-			"yandex_resourcemanager_cloud_iam_binding":                resourceYandexResourceManagerCloudIAMBinding(),
-			"yandex_resourcemanager_cloud_iam_member":                 resourceYandexResourceManagerCloudIAMMember(),
-			"yandex_resourcemanager_folder":                           resourceYandexResourceManagerFolder(),
-			"yandex_resourcemanager_folder_iam_binding":               resourceYandexResourceManagerFolderIAMBinding(),
-			"yandex_resourcemanager_folder_iam_member":                resourceYandexResourceManagerFolderIAMMember(),
-			"yandex_resourcemanager_folder_iam_policy":                resourceYandexResourceManagerFolderIAMPolicy(),
-			"yandex_serverless_container":                             resourceYandexServerlessContainer(),
-			"yandex_serverless_container_iam_binding":                 resourceYandexServerlessContainerIAMBinding(),
-			"yandex_storage_bucket":                                   resourceYandexStorageBucket(),
-			"yandex_storage_object":                                   resourceYandexStorageObject(),
-			"yandex_vpc_address":                                      resourceYandexVPCAddress(),
-			"yandex_vpc_default_security_group":                       resourceYandexVPCDefaultSecurityGroup(),
-			"yandex_vpc_gateway":                                      resourceYandexVPCGateway(),
-			"yandex_vpc_network":                                      resourceYandexVPCNetwork(),
-			"yandex_vpc_route_table":                                  resourceYandexVPCRouteTable(),
-			"yandex_vpc_security_group":                               resourceYandexVPCSecurityGroup(),
-			"yandex_vpc_security_group_rule":                          resourceYandexVpcSecurityGroupRule(),
-			"yandex_vpc_subnet":                                       resourceYandexVPCSubnet(),
-			"yandex_ydb_database_iam_binding":                         resourceYandexYDBDatabaseIAMBinding(),
-			"yandex_ydb_database_dedicated":                           resourceYandexYDBDatabaseDedicated(),
-			"yandex_ydb_database_serverless":                          resourceYandexYDBDatabaseServerless(),
-			"yandex_ydb_topic":                                        resourceYandexYDBTopic(),
-			"yandex_ydb_table":                                        resourceYandexYDBTable(),
-			"yandex_ydb_table_changefeed":                             resourceYandexYDBTableChangefeed(),
-			"yandex_ydb_table_index":                                  resourceYandexYDBTableIndex(),
-			"yandex_sws_security_profile":                             resourceYandexSmartwebsecuritySecurityProfile(),
-			"yandex_smartcaptcha_captcha":                             resourceYandexSmartcaptchaCaptcha(),
+			"yandex_alb_backend_group":                                   resourceYandexALBBackendGroup(),
+			"yandex_alb_http_router":                                     resourceYandexALBHTTPRouter(),
+			"yandex_alb_load_balancer":                                   resourceYandexALBLoadBalancer(),
+			"yandex_alb_target_group":                                    resourceYandexALBTargetGroup(),
+			"yandex_alb_virtual_host":                                    addPassthroughImport(withALBVirtualHostID(resourceYandexALBVirtualHost())),
+			"yandex_api_gateway":                                         resourceYandexApiGateway(),
+			"yandex_audit_trails_trail":                                  resourceYandexAuditTrailsTrail(),
+			"yandex_backup_policy":                                       resourceYandexBackupPolicy(),
+			"yandex_backup_policy_bindings":                              resourceYandexBackupPolicyBindings(),
+			"yandex_container_registry":                                  resourceYandexContainerRegistry(),
+			"yandex_container_registry_iam_binding":                      resourceYandexContainerRegistryIAMBinding(),
+			"yandex_container_registry_ip_permission":                    resourceYandexContainerRegistryIPPermission(),
+			"yandex_container_repository":                                resourceYandexContainerRepository(),
+			"yandex_container_repository_iam_binding":                    resourceYandexContainerRepositoryIAMBinding(),
+			"yandex_container_repository_lifecycle_policy":               resourceYandexContainerRepositoryLifecyclePolicy(),
+			"yandex_cdn_origin_group":                                    resourceYandexCDNOriginGroup(),
+			"yandex_cdn_resource":                                        resourceYandexCDNResource(),
+			"yandex_cm_certificate":                                      resourceYandexCMCertificate(),
+			"yandex_compute_disk":                                        resourceYandexComputeDisk(),
+			"yandex_compute_disk_placement_group":                        resourceYandexComputeDiskPlacementGroup(),
+			"yandex_compute_filesystem":                                  resourceYandexComputeFilesystem(),
+			"yandex_compute_gpu_cluster":                                 resourceYandexComputeGpuCluster(),
+			"yandex_compute_image":                                       resourceYandexComputeImage(),
+			"yandex_compute_instance":                                    resourceYandexComputeInstance(),
+			"yandex_compute_instance_group":                              resourceYandexComputeInstanceGroup(),
+			"yandex_compute_instance_rolling_resize":                     resourceYandexComputeInstanceRollingResize(),
+			"yandex_compute_placement_group":                             resourceYandexComputePlacementGroup(),
+			"yandex_compute_snapshot":                                    resourceYandexComputeSnapshot(),
+			"yandex_compute_snapshot_schedule":                           resourceYandexComputeSnapshotSchedule(),
+			"yandex_dataproc_cluster":                                    resourceYandexDataprocCluster(),
+			"yandex_datatransfer_endpoint":                               resourceYandexDatatransferEndpoint(),
+			"yandex_datatransfer_transfer":                               resourceYandexDatatransferTransfer(),
+			"yandex_dns_zone_iam_binding":                                resourceYandexDnsZoneIAMBinding(),
+			"yandex_dns_recordset":                                       resourceYandexDnsRecordSet(),
+			"yandex_dns_zone":                                            resourceYandexDnsZone(),
+			"yandex_function":                                            resourceYandexFunction(),
+			"yandex_function_iam_binding":                                resourceYandexFunctionIAMBinding(),
+			"yandex_function_scaling_policy":                             resourceYandexFunctionScalingPolicy(),
+			"yandex_function_trigger":                                    resourceYandexFunctionTrigger(),
+			"yandex_iam_service_account":                                 resourceYandexIAMServiceAccount(),
+			"yandex_iam_service_account_api_key":                         resourceYandexIAMServiceAccountAPIKey(),
+			"yandex_iam_service_account_iam_binding":                     resourceYandexIAMServiceAccountIAMBinding(),
+			"yandex_iam_service_account_iam_member":                      resourceYandexIAMServiceAccountIAMMember(),
+			"yandex_iam_service_account_iam_policy":                      resourceYandexIAMServiceAccountIAMPolicy(),
+			"yandex_iam_service_account_key":                             resourceYandexIAMServiceAccountKey(),
+			"yandex_iam_service_account_static_access_key":               resourceYandexIAMServiceAccountStaticAccessKey(),
+			"yandex_iot_core_broker":                                     resourceYandexIoTCoreBroker(),
+			"yandex_iot_core_device":                                     resourceYandexIoTCoreDevice(),
+			"yandex_iot_core_registry":                                   resourceYandexIoTCoreRegistry(),
+			"yandex_kms_secret_ciphertext":                               resourceYandexKMSSecretCiphertext(),
+			"yandex_kms_symmetric_key":                                   resourceYandexKMSSymmetricKey(),
+			"yandex_kms_symmetric_key_iam_binding":                       resourceYandexKMSSymmetricKeyIAMBinding(),
+			"yandex_kms_asymmetric_encryption_key":                       resourceYandexKMSAsymmetricEncryptionKey(),
+			"yandex_kms_asymmetric_encryption_key_iam_binding":           resourceYandexKMSAsymmetricEncryptionKeyIAMBinding(),
+			"yandex_kms_asymmetric_signature_key":                        resourceYandexKMSAsymmetricSignatureKey(),
+			"yandex_kms_asymmetric_signature_key_iam_binding":            resourceYandexKMSAsymmetricSignatureKeyIAMBinding(),
+			"yandex_kubernetes_cluster":                                  resourceYandexKubernetesCluster(),
+			"yandex_kubernetes_node_group":                               resourceYandexKubernetesNodeGroup(),
+			"yandex_lb_network_load_balancer":                            resourceYandexLBNetworkLoadBalancer(),
+			"yandex_lb_target_group":                                     resourceYandexLBTargetGroup(),
+			"yandex_loadtesting_agent":                                   resourceYandexLoadtestingAgent(),
+			"yandex_lockbox_secret":                                      resourceYandexLockboxSecret(),
+			"yandex_lockbox_secret_version":                              resourceYandexLockboxSecretVersion(),
+			"yandex_lockbox_secret_version_hashed":                       resourceYandexLockboxSecretVersionHashed(),
+			"yandex_lockbox_secret_iam_binding":                          resourceYandexLockboxSecretIAMBinding(),
+			"yandex_logging_group":                                       resourceYandexLoggingGroup(),
+			"yandex_mdb_clickhouse_cluster":                              resourceYandexMDBClickHouseCluster(),
+			"yandex_mdb_elasticsearch_cluster":                           resourceYandexMDBElasticsearchCluster(),
+			"yandex_mdb_greenplum_cluster":                               resourceYandexMDBGreenplumCluster(),
+			"yandex_mdb_kafka_cluster":                                   resourceYandexMDBKafkaCluster(),
+			"yandex_mdb_kafka_topic":                                     resourceYandexMDBKafkaTopic(),
+			"yandex_mdb_kafka_connector":                                 resourceYandexMDBKafkaConnector(),
+			"yandex_mdb_kafka_user":                                      resourceYandexMDBKafkaUser(),
+			"yandex_mdb_mongodb_cluster":                                 resourceYandexMDBMongodbCluster(),
+			"yandex_mdb_mysql_cluster":                                   resourceYandexMDBMySQLCluster(),
+			"yandex_mdb_mysql_database":                                  resourceYandexMDBMySQLDatabase(),
+			"yandex_mdb_mysql_user":                                      resourceYandexMDBMySQLUser(),
+			"yandex_mdb_postgresql_cluster":                              resourceYandexMDBPostgreSQLCluster(),
+			"yandex_mdb_postgresql_database":                             resourceYandexMDBPostgreSQLDatabase(),
+			"yandex_mdb_postgresql_user":                                 resourceYandexMDBPostgreSQLUser(),
+			"yandex_mdb_redis_cluster":                                   resourceYandexMDBRedisCluster(),
+			"yandex_mdb_sqlserver_cluster":                               resourceYandexMDBSQLServerCluster(),
+			"yandex_message_queue":                                       resourceYandexMessageQueue(),
+			"yandex_monitoring_dashboard":                                resourceYandexMonitoringDashboard(),
+			"yandex_organizationmanager_organization_iam_binding":        resourceYandexOrganizationManagerOrganizationIAMBinding(),
+			"yandex_organizationmanager_organization_iam_member":         resourceYandexOrganizationManagerOrganizationIAMMember(),
+			"yandex_organizationmanager_saml_federation":                 resourceYandexOrganizationManagerSamlFederation(),
+			"yandex_organizationmanager_saml_federation_user_account":    resourceYandexOrganizationManagerSamlFederationUserAccount(),
+			"yandex_organizationmanager_group":                           resourceYandexOrganizationManagerGroup(),
+			"yandex_organizationmanager_group_iam_member":                resourceYandexOrganizationManagerGroupIAMMember(),
+			"yandex_organizationmanager_group_membership":                resourceYandexOrganizationManagerGroupMembership(),
+			"yandex_organizationmanager_os_login_settings":               resourceYandexOrganizationManagerOsLoginSettings(),
+			"yandex_organizationmanager_user_ssh_key":                    resourceYandexOrganizationManagerUserSshKey(),
+			"yandex_resourcemanager_cloud":                               resourceYandexResourceManagerCloud(),
+			"yandex_resourcemanager_cloud_iam_binding":                   resourceYandexResourceManagerCloudIAMBinding(),
+			"yandex_resourcemanager_cloud_iam_member":                    resourceYandexResourceManagerCloudIAMMember(),
+			"yandex_resourcemanager_folder":                              resourceYandexResourceManagerFolder(),
+			"yandex_resourcemanager_folder_iam_binding":                  resourceYandexResourceManagerFolderIAMBinding(),
+			"yandex_resourcemanager_folder_iam_member":                   resourceYandexResourceManagerFolderIAMMember(),
+			"yandex_resourcemanager_folder_iam_policy":                   resourceYandexResourceManagerFolderIAMPolicy(),
+			"yandex_serverless_container":                                resourceYandexServerlessContainer(),
+			"yandex_serverless_container_iam_binding":                    resourceYandexServerlessContainerIAMBinding(),
+			"yandex_storage_bucket":                                      resourceYandexStorageBucket(),
+			"yandex_storage_bucket_cors_configuration":                   resourceYandexStorageBucketCORSConfiguration(),
+			"yandex_storage_bucket_inventory":                            resourceYandexStorageBucketInventory(),
+			"yandex_storage_bucket_lifecycle_configuration":              resourceYandexStorageBucketLifecycleConfiguration(),
+			"yandex_storage_bucket_logging":                              resourceYandexStorageBucketLogging(),
+			"yandex_storage_bucket_notification":                         resourceYandexStorageBucketNotification(),
+			"yandex_storage_bucket_policy":                               resourceYandexStorageBucketPolicy(),
+			"yandex_storage_bucket_server_side_encryption_configuration": resourceYandexStorageBucketServerSideEncryptionConfiguration(),
+			"yandex_storage_bucket_versioning":                           resourceYandexStorageBucketVersioning(),
+			"yandex_storage_bucket_website_configuration":                resourceYandexStorageBucketWebsiteConfiguration(),
+			"yandex_storage_directory":                                   resourceYandexStorageDirectory(),
+			"yandex_storage_object":                                      resourceYandexStorageObject(),
+			"yandex_vpc_address":                                         resourceYandexVPCAddress(),
+			"yandex_vpc_default_security_group":                          resourceYandexVPCDefaultSecurityGroup(),
+			"yandex_vpc_gateway":                                         resourceYandexVPCGateway(),
+			"yandex_vpc_network":                                         resourceYandexVPCNetwork(),
+			"yandex_vpc_route_table":                                     resourceYandexVPCRouteTable(),
+			"yandex_vpc_security_group":                                  resourceYandexVPCSecurityGroup(),
+			"yandex_vpc_security_group_rule":                             resourceYandexVpcSecurityGroupRule(),
+			"yandex_vpc_subnet":                                          resourceYandexVPCSubnet(),
+			"yandex_ydb_database_iam_binding":                            resourceYandexYDBDatabaseIAMBinding(),
+			"yandex_ydb_database_dedicated":                              resourceYandexYDBDatabaseDedicated(),
+			"yandex_ydb_database_serverless":                             resourceYandexYDBDatabaseServerless(),
+			"yandex_ydb_topic":                                           resourceYandexYDBTopic(),
+			"yandex_ydb_table":                                           resourceYandexYDBTable(),
+			"yandex_ydb_table_changefeed":                                resourceYandexYDBTableChangefeed(),
+			"yandex_ydb_table_index":                                     resourceYandexYDBTableIndex(),
+			"yandex_sws_security_profile":                                resourceYandexSmartwebsecuritySecurityProfile(),
+			"yandex_smartcaptcha_captcha":                                resourceYandexSmartcaptchaCaptcha(),
 		},
 	}
 
@@ -408,18 +602,152 @@ func setToDefaultBoolIfNeeded(osEnvName string, defaultVal bool) bool {
 	}
 }
 
+func retryCodesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: common.Descriptions["retry_codes"],
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+func retryDelaySchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: common.Descriptions[description],
+	}
+}
+
+func retryMaxRetriesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Description: common.Descriptions["retry_max_retries"],
+	}
+}
+
+func expandRetryPolicy(v []interface{}, maxRetries int) (*retrypolicy.Policy, error) {
+	var defaults, operationPoll retrypolicy.RawSettings
+	var services []retrypolicy.RawSettings
+
+	if len(v) > 0 && v[0] != nil {
+		retry := v[0].(map[string]interface{})
+		defaults = expandProviderRetrySettings(retry)
+		if op := retry["operation_poll"].([]interface{}); len(op) > 0 && op[0] != nil {
+			operationPoll = expandProviderRetrySettings(op[0].(map[string]interface{}))
+		}
+		for _, service := range retry["service"].([]interface{}) {
+			if service != nil {
+				services = append(services, expandProviderRetrySettings(service.(map[string]interface{})))
+			}
+		}
+	}
+
+	return retrypolicy.Build(maxRetries, defaults, operationPoll, services)
+}
+
+func expandProviderRetrySettings(m map[string]interface{}) retrypolicy.RawSettings {
+	s := retrypolicy.RawSettings{
+		Codes:     expandStringSlice(m["codes"].([]interface{})),
+		BaseDelay: m["base_delay"].(string),
+		MaxDelay:  m["max_delay"].(string),
+	}
+	if v, ok := m["name"]; ok {
+		s.Service = v.(string)
+	}
+	if v, ok := m["max_retries"]; ok {
+		s.MaxRetries = v.(int)
+	}
+	return s
+}
+
+func rateLimitRequestsPerSecondSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeFloat,
+		Optional:    true,
+		Description: common.Descriptions["rate_limit_requests_per_second"],
+	}
+}
+
+func rateLimitMaxInFlightSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Description: common.Descriptions["rate_limit_max_in_flight"],
+	}
+}
+
+func expandTracing(v []interface{}) *tracing.Settings {
+	if len(v) == 0 {
+		return nil
+	}
+	if v[0] == nil {
+		// Empty block enables tracing with settings from environment.
+		return &tracing.Settings{}
+	}
+
+	m := v[0].(map[string]interface{})
+	return &tracing.Settings{
+		Endpoint: m["otlp_endpoint"].(string),
+		Insecure: m["insecure"].(bool),
+	}
+}
+
+func expandRateLimitPolicy(v []interface{}) (*ratelimit.Policy, error) {
+	if len(v) == 0 || v[0] == nil {
+		return nil, nil
+	}
+
+	rateLimit := v[0].(map[string]interface{})
+	var services []ratelimit.RawSettings
+	for _, service := range rateLimit["service"].([]interface{}) {
+		if service == nil {
+			continue
+		}
+		m := service.(map[string]interface{})
+		services = append(services, ratelimit.RawSettings{
+			Service:  m["name"].(string),
+			Settings: expandRateLimitSettings(m),
+		})
+	}
+
+	return ratelimit.Build(expandRateLimitSettings(rateLimit), services)
+}
+
+func expandRateLimitSettings(m map[string]interface{}) ratelimit.Settings {
+	return ratelimit.Settings{
+		RequestsPerSecond: m["requests_per_second"].(float64),
+		MaxInFlight:       m["max_in_flight"].(int),
+	}
+}
+
 // testConfig is used to avoid using StopContext duo to tests are run in parallel and context is cancelled randomly in tests
 // there is same following issue https://github.com/hashicorp/terraform-plugin-sdk/issues/966
 func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Provider, emptyFolder bool, testConfig bool) (interface{}, diag.Diagnostics) {
+	cliProfile, err := loadCLIProfile(
+		setToDefaultIfNeeded(d.Get("yc_config_file").(string), "YC_CLI_CONFIG_FILE", ""),
+		setToDefaultIfNeeded(d.Get("yc_profile").(string), "YC_CLI_PROFILE", ""),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
 	config := Config{
-		Endpoint:                       setToDefaultIfNeeded(d.Get("endpoint").(string), "YC_ENDPOINT", common.DefaultEndpoint),
-		FolderID:                       setToDefaultIfNeeded(d.Get("folder_id").(string), "YC_FOLDER_ID", ""),
-		CloudID:                        setToDefaultIfNeeded(d.Get("cloud_id").(string), "YC_CLOUD_ID", ""),
-		OrganizationID:                 setToDefaultIfNeeded(d.Get("organization_id").(string), "YC_ORGANIZATION_ID", ""),
+		Endpoint:                       setToDefaultIfNeeded(d.Get("endpoint").(string), "YC_ENDPOINT", ycprofile.FirstNonEmpty(cliProfile.Endpoint, common.DefaultEndpoint)),
+		FolderID:                       setToDefaultIfNeeded(d.Get("folder_id").(string), "YC_FOLDER_ID", cliProfile.FolderID),
+		CloudID:                        setToDefaultIfNeeded(d.Get("cloud_id").(string), "YC_CLOUD_ID", cliProfile.CloudID),
+		OrganizationID:                 setToDefaultIfNeeded(d.Get("organization_id").(string), "YC_ORGANIZATION_ID", cliProfile.OrganizationID),
 		Region:                         setToDefaultIfNeeded(d.Get("region_id").(string), "YC_REGION", common.DefaultRegion),
-		Zone:                           setToDefaultIfNeeded(d.Get("zone").(string), "YC_ZONE", ""),
+		Zone:                           setToDefaultIfNeeded(d.Get("zone").(string), "YC_ZONE", cliProfile.Zone),
 		Token:                          setToDefaultIfNeeded(d.Get("token").(string), "YC_TOKEN", ""),
 		ServiceAccountKeyFileOrContent: setToDefaultIfNeeded(d.Get("service_account_key_file").(string), "YC_SERVICE_ACCOUNT_KEY_FILE", ""),
+		OIDCToken:                      setToDefaultIfNeeded(d.Get("oidc_token").(string), "YC_OIDC_TOKEN", ""),
+		OIDCTokenFile:                  setToDefaultIfNeeded(d.Get("oidc_token_file").(string), "YC_OIDC_TOKEN_FILE", ""),
+		OIDCServiceAccountID:           setToDefaultIfNeeded(d.Get("oidc_service_account_id").(string), "YC_OIDC_SERVICE_ACCOUNT_ID", ""),
+		OIDCTokenExchangeEndpoint:      setToDefaultIfNeeded(d.Get("oidc_token_exchange_endpoint").(string), "YC_OIDC_TOKEN_EXCHANGE_ENDPOINT", common.DefaultTokenExchangeEndpoint),
+		ImpersonateServiceAccountID:    setToDefaultIfNeeded(d.Get("impersonate_service_account_id").(string), "YC_IMPERSONATE_SERVICE_ACCOUNT_ID", ""),
+		AuditLogFile:                   setToDefaultIfNeeded(d.Get("audit_log_file").(string), "YC_AUDIT_LOG_FILE", ""),
 		StorageEndpoint:                setToDefaultIfNeeded(d.Get("storage_endpoint").(string), "YC_STORAGE_ENDPOINT_URL", common.DefaultStorageEndpoint),
 		StorageAccessKey:               setToDefaultIfNeeded(d.Get("storage_access_key").(string), "YC_STORAGE_ACCESS_KEY", ""),
 		StorageSecretKey:               setToDefaultIfNeeded(d.Get("storage_secret_key").(string), "YC_STORAGE_SECRET_KEY", ""),
@@ -427,12 +755,23 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Pr
 		YMQAccessKey:                   setToDefaultIfNeeded(d.Get("ymq_access_key").(string), "YC_MESSAGE_QUEUE_ACCESS_KEY", ""),
 		YMQSecretKey:                   setToDefaultIfNeeded(d.Get("ymq_secret_key").(string), "YC_MESSAGE_QUEUE_SECRET_KEY", ""),
 
-		Plaintext:             setToDefaultBoolIfNeeded("YC_PLAINTEXT", d.Get("plaintext").(bool)),
-		Insecure:              setToDefaultBoolIfNeeded("YC_INSECURE", d.Get("insecure").(bool)),
-		MaxRetries:            d.Get("max_retries").(int),
-		SharedCredentialsFile: d.Get("shared_credentials_file").(string),
-		Profile:               d.Get("profile").(string),
-		userAgent:             p.UserAgent("terraform-provider-yandex", version.ProviderVersion),
+		Transport: transport.Settings{
+			CAFile:    setToDefaultIfNeeded(d.Get("ca_file").(string), "YC_CA_FILE", ""),
+			CAContent: d.Get("ca_content").(string),
+			HTTPProxy: d.Get("http_proxy").(string),
+			NoProxy:   d.Get("no_proxy").(string),
+		},
+
+		Plaintext:                setToDefaultBoolIfNeeded("YC_PLAINTEXT", d.Get("plaintext").(bool)),
+		Insecure:                 setToDefaultBoolIfNeeded("YC_INSECURE", d.Get("insecure").(bool)),
+		MaxRetries:               d.Get("max_retries").(int),
+		SharedCredentialsFile:    d.Get("shared_credentials_file").(string),
+		Profile:                  d.Get("profile").(string),
+		AllowTemporaryAccessKeys: d.Get("allow_temporary_access_keys").(bool),
+		userAgent:                p.UserAgent("terraform-provider-yandex", version.ProviderVersion),
+		cliProfile:               cliProfile,
+
+		StorageBucketStandaloneConfigurations: d.Get("storage_bucket_standalone_configurations").(bool),
 	}
 
 	if len(config.Profile) == 0 {
@@ -443,6 +782,23 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Pr
 		config.MaxRetries = common.DefaultMaxRetries
 	}
 
+	config.RetryPolicy, err = expandRetryPolicy(d.Get("retry").([]interface{}), config.MaxRetries)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	config.RateLimitPolicy, err = expandRateLimitPolicy(d.Get("rate_limit").([]interface{}))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	config.Tracing = expandTracing(d.Get("tracing").([]interface{}))
+
+	config.Endpoints, err = endpoints.Build(convertStringMap(d.Get("endpoints").(map[string]interface{})))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
 	if emptyFolder {
 		config.FolderID = ""
 	}
@@ -469,6 +825,15 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Pr
 
 }
 
+// loadCLIProfile reads yc CLI profile if user has asked for it, and returns an empty profile otherwise,
+// so that its fields can be used as fallback values unconditionally.
+func loadCLIProfile(filename, name string) (*ycprofile.Profile, error) {
+	if filename == "" && name == "" {
+		return &ycprofile.Profile{}, nil
+	}
+	return ycprofile.Load(filename, name)
+}
+
 func validateSAKey(v interface{}, k string) (warnings []string, errors []error) {
 	if v == nil || v.(string) == "" {
 		return
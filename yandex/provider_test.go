@@ -2,24 +2,33 @@ package yandex
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	terraform2 "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/logging"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/retrypolicy"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/resourcemanager/v1"
 	ycsdk "github.com/yandex-cloud/go-sdk"
+	"google.golang.org/grpc/codes"
 )
 
 const providerDefaultValueInsecure = false
@@ -224,6 +233,87 @@ func TestProviderSharedCredentialsFileAndProfile(t *testing.T) {
 	assert.Equal(t, "prod-profile", conf.Profile)
 }
 
+func TestProviderYCProfile(t *testing.T) {
+	envVars := []string{"YC_ENDPOINT", "YC_FOLDER_ID", "YC_CLOUD_ID", "YC_ZONE", "YC_ORGANIZATION_ID", "YC_TOKEN",
+		"YC_SERVICE_ACCOUNT_KEY_FILE", "YC_CLI_PROFILE", "YC_CLI_CONFIG_FILE"}
+	saveEnvVariable := saveAndUnsetEnvVars(envVars)
+	defer func() {
+		if err := restoreEnvVars(saveEnvVariable); err != nil {
+			t.Fatal("failed to restore OS env vars:", envVars, "after test", t.Name(), " - error:", err)
+		}
+	}()
+
+	cases := []struct {
+		name     string
+		raw      map[string]interface{}
+		env      map[string]string
+		expected Config
+	}{
+		{
+			name: "current profile",
+			raw: map[string]interface{}{
+				"yc_config_file": "test-fixtures/yc-cli-config.yaml",
+			},
+			expected: Config{
+				Endpoint: common.DefaultEndpoint,
+				FolderID: "profile-folder-id",
+				CloudID:  "profile-cloud-id",
+				Zone:     "ru-central1-b",
+			},
+		},
+		{
+			name: "explicit values take precedence over profile",
+			raw: map[string]interface{}{
+				"yc_config_file": "test-fixtures/yc-cli-config.yaml",
+				"yc_profile":     "private",
+				"folder_id":      "explicit-folder-id",
+			},
+			env: map[string]string{
+				"YC_ENDPOINT": "api.env.example:443",
+			},
+			expected: Config{
+				Endpoint:       "api.env.example:443",
+				FolderID:       "explicit-folder-id",
+				OrganizationID: "private-organization-id",
+			},
+		},
+		{
+			name: "profile selected via env",
+			raw:  map[string]interface{}{},
+			env: map[string]string{
+				"YC_CLI_CONFIG_FILE": "test-fixtures/yc-cli-config.yaml",
+				"YC_CLI_PROFILE":     "private",
+			},
+			expected: Config{
+				Endpoint:       "api.private.example:443",
+				FolderID:       "private-folder-id",
+				OrganizationID: "private-organization-id",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			testProvider := NewSDKProvider()
+			diags := testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(tc.raw))
+			if diags.HasError() {
+				t.Fatalf("error configuring provider: %v", diags)
+			}
+
+			conf := testProvider.Meta().(*Config)
+			assert.Equal(t, tc.expected.Endpoint, conf.Endpoint)
+			assert.Equal(t, tc.expected.FolderID, conf.FolderID)
+			assert.Equal(t, tc.expected.CloudID, conf.CloudID)
+			assert.Equal(t, tc.expected.OrganizationID, conf.OrganizationID)
+			assert.Equal(t, tc.expected.Zone, conf.Zone)
+		})
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	for _, varName := range testAccEnvVars {
 		if val := os.Getenv(varName); val == "" {
@@ -401,3 +491,159 @@ func restoreEnvVars(storage map[string]string) error {
 	}
 	return nil
 }
+
+func TestProviderRetry(t *testing.T) {
+	envVars := []string{"YC_TOKEN", "YC_SERVICE_ACCOUNT_KEY_FILE", "YC_CLI_PROFILE", "YC_CLI_CONFIG_FILE"}
+	saveEnvVariable := saveAndUnsetEnvVars(envVars)
+	defer func() {
+		if err := restoreEnvVars(saveEnvVariable); err != nil {
+			t.Fatal("failed to restore OS env vars:", envVars, "after test", t.Name(), " - error:", err)
+		}
+	}()
+
+	raw := map[string]interface{}{
+		"token":       "any_string_like_a_oauth",
+		"max_retries": 3,
+		"retry": []interface{}{
+			map[string]interface{}{
+				"codes":      []interface{}{"UNAVAILABLE", "RESOURCE_EXHAUSTED"},
+				"base_delay": "100ms",
+				"operation_poll": []interface{}{
+					map[string]interface{}{
+						"max_retries": 10,
+					},
+				},
+				"service": []interface{}{
+					map[string]interface{}{
+						"name":  "mdb",
+						"codes": []interface{}{"INTERNAL"},
+					},
+				},
+			},
+		},
+	}
+
+	testProvider := NewSDKProvider()
+	diags := testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+
+	policy := testProvider.Meta().(*Config).RetryPolicy
+	assert.Equal(t, 3, policy.Default.MaxRetries)
+	assert.Equal(t, []codes.Code{codes.Unavailable, codes.ResourceExhausted}, policy.Default.Codes)
+	assert.Equal(t, 100*time.Millisecond, policy.Default.BaseDelay)
+	assert.Equal(t, 10, policy.OperationPoll.MaxRetries)
+	assert.Equal(t, retrypolicy.DefaultOperationPollCodes, policy.OperationPoll.Codes)
+	assert.Equal(t, []codes.Code{codes.Internal}, policy.Services["mdb"].Codes)
+	assert.Equal(t, 3, policy.Services["mdb"].MaxRetries)
+
+	raw["retry"] = []interface{}{
+		map[string]interface{}{
+			"codes": []interface{}{"SOMETIMES"},
+		},
+	}
+	diags = NewSDKProvider().Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
+	assert.True(t, diags.HasError())
+}
+
+func TestProviderRateLimit(t *testing.T) {
+	envVars := []string{"YC_TOKEN", "YC_SERVICE_ACCOUNT_KEY_FILE", "YC_CLI_PROFILE", "YC_CLI_CONFIG_FILE"}
+	saveEnvVariable := saveAndUnsetEnvVars(envVars)
+	defer func() {
+		if err := restoreEnvVars(saveEnvVariable); err != nil {
+			t.Fatal("failed to restore OS env vars:", envVars, "after test", t.Name(), " - error:", err)
+		}
+	}()
+
+	raw := map[string]interface{}{
+		"token": "any_string_like_a_oauth",
+	}
+	testProvider := NewSDKProvider()
+	diags := testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+	assert.Nil(t, testProvider.Meta().(*Config).RateLimitPolicy)
+
+	raw["rate_limit"] = []interface{}{
+		map[string]interface{}{
+			"requests_per_second": 50,
+			"service": []interface{}{
+				map[string]interface{}{
+					"name":          "compute",
+					"max_in_flight": 10,
+				},
+			},
+		},
+	}
+	testProvider = NewSDKProvider()
+	diags = testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+
+	policy := testProvider.Meta().(*Config).RateLimitPolicy
+	assert.NotNil(t, policy.Default)
+	assert.NotNil(t, policy.Services["compute"])
+	assert.Same(t, policy.Services["compute"], policy.LimiterFor("/yandex.cloud.compute.v1.InstanceService/Get"))
+}
+
+func TestProviderTransport(t *testing.T) {
+	envVars := []string{"YC_TOKEN", "YC_SERVICE_ACCOUNT_KEY_FILE", "YC_CLI_PROFILE", "YC_CLI_CONFIG_FILE", "YC_CA_FILE",
+		"HTTPS_PROXY", "NO_PROXY", "YDB_SSL_ROOT_CERTIFICATES_FILE"}
+	saveEnvVariable := saveAndUnsetEnvVars(envVars)
+	defer func() {
+		if err := restoreEnvVars(saveEnvVariable); err != nil {
+			t.Fatal("failed to restore OS env vars:", envVars, "after test", t.Name(), " - error:", err)
+		}
+	}()
+
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	caContent := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	raw := map[string]interface{}{
+		"token": "any_string_like_a_oauth",
+	}
+	testProvider := NewSDKProvider()
+	diags := testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+	assert.Nil(t, testProvider.Meta().(*Config).httpClient(storageServiceName, logging.AuditProtocolS3))
+
+	raw["ca_content"] = caContent
+	raw["http_proxy"] = "http://proxy.local:3128"
+	raw["no_proxy"] = "internal.local"
+	testProvider = NewSDKProvider()
+	diags = testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("error configuring provider: %v", diags)
+	}
+
+	config := testProvider.Meta().(*Config)
+	client := config.httpClient(storageServiceName, logging.AuditProtocolS3)
+	require.NotNil(t, client)
+	httpTransport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.NotNil(t, httpTransport.TLSClientConfig.RootCAs)
+
+	proxyURL, err := httpTransport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "storage.yandexcloud.net"}})
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy.local:3128", proxyURL.String())
+
+	// YDB SDK takes the settings from environment.
+	assert.Equal(t, "http://proxy.local:3128", os.Getenv("HTTPS_PROXY"))
+	assert.Equal(t, "internal.local", os.Getenv("NO_PROXY"))
+	ydbCAFile := os.Getenv("YDB_SSL_ROOT_CERTIFICATES_FILE")
+	require.NotEmpty(t, ydbCAFile)
+	ydbCA, err := os.ReadFile(ydbCAFile)
+	require.NoError(t, err)
+	assert.Equal(t, caContent, string(ydbCA))
+
+	raw["ca_content"] = "not a certificate"
+	testProvider = NewSDKProvider()
+	diags = testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
+	assert.True(t, diags.HasError())
+}
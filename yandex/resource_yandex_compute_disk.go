@@ -30,7 +30,10 @@ func resourceYandexComputeDisk() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
-		CustomizeDiff: customdiff.ForceNewIfChange("size", isDiskSizeDecreased),
+		CustomizeDiff: customdiff.All(
+			customdiff.ForceNewIfChange("size", isDiskSizeDecreased),
+			resourceYandexComputeDiskZoneCustomizeDiff,
+		),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(yandexComputeDiskDefaultTimeout),
@@ -69,7 +72,6 @@ func resourceYandexComputeDisk() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 				Optional: true,
-				ForceNew: true,
 			},
 
 			"size": {
@@ -91,14 +93,22 @@ func resourceYandexComputeDisk() *schema.Resource {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"snapshot_id"},
+				ConflictsWith: []string{"snapshot_id", "source_disk_id"},
 			},
 
 			"snapshot_id": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"image_id"},
+				ConflictsWith: []string{"image_id", "source_disk_id"},
+			},
+
+			"source_disk_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"image_id", "snapshot_id"},
 			},
 
 			"type": {
@@ -143,6 +153,11 @@ func resourceYandexComputeDisk() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+
+			"allow_zone_migration": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -170,24 +185,77 @@ func flattenDiskPlacementPolicy(disk *compute.Disk) ([]map[string]interface{}, e
 func resourceYandexComputeDiskCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
+	req, err := prepareCreateDiskRequest(d, config)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(config.Context(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	if v, ok := d.GetOk("source_disk_id"); ok {
+		snapshotID, err := createComputeDiskTemporarySnapshot(ctx, config, v.(string), req.FolderId,
+			fmt.Sprintf("Temporary snapshot to clone disk %s", v.(string)))
+		if snapshotID != "" {
+			// The intermediate snapshot is deleted even if the disk is not created
+			defer deleteComputeDiskTemporarySnapshot(config, snapshotID)
+		}
+		if err != nil {
+			return err
+		}
+		req.Source = &compute.CreateDiskRequest_SnapshotId{
+			SnapshotId: snapshotID,
+		}
+	}
+
+	op, err := config.sdk.WrapOperation(config.sdk.Compute().Disk().Create(ctx, req))
+	if err != nil {
+		return fmt.Errorf("Error while requesting API to create disk: %s", err)
+	}
+
+	protoMetadata, err := op.Metadata()
+	if err != nil {
+		return fmt.Errorf("Error while get disk create operation metadata: %s", err)
+	}
+
+	md, ok := protoMetadata.(*compute.CreateDiskMetadata)
+	if !ok {
+		return fmt.Errorf("could not get Disk ID from create operation metadata")
+	}
+
+	d.SetId(md.DiskId)
+
+	err = op.Wait(ctx)
+	if err != nil {
+		return fmt.Errorf("Error while waiting operation to create disk: %s", err)
+	}
+
+	if _, err := op.Response(); err != nil {
+		return fmt.Errorf("Disk creation failed: %s", err)
+	}
+
+	return resourceYandexComputeDiskRead(d, meta)
+}
+
+func prepareCreateDiskRequest(d *schema.ResourceData, config *Config) (*compute.CreateDiskRequest, error) {
 	zone, err := getZone(d, config)
 	if err != nil {
-		return fmt.Errorf("Error getting zone while creating disk: %s", err)
+		return nil, fmt.Errorf("Error getting zone while creating disk: %s", err)
 	}
 
 	folderID, err := getFolderID(d, config)
 	if err != nil {
-		return fmt.Errorf("Error getting folder ID while creating disk: %s", err)
+		return nil, fmt.Errorf("Error getting folder ID while creating disk: %s", err)
 	}
 
 	labels, err := expandLabels(d.Get("labels"))
 	if err != nil {
-		return fmt.Errorf("Error expanding labels while creating disk: %s", err)
+		return nil, fmt.Errorf("Error expanding labels while creating disk: %s", err)
 	}
 
 	diskPlacementPolicy, err := expandDiskPlacementPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error expanding disk placement policy while creating disk: %s", err)
+		return nil, fmt.Errorf("Error expanding disk placement policy while creating disk: %s", err)
 	}
 
 	req := compute.CreateDiskRequest{
@@ -212,36 +280,7 @@ func resourceYandexComputeDiskCreate(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(config.Context(), d.Timeout(schema.TimeoutCreate))
-	defer cancel()
-
-	op, err := config.sdk.WrapOperation(config.sdk.Compute().Disk().Create(ctx, &req))
-	if err != nil {
-		return fmt.Errorf("Error while requesting API to create disk: %s", err)
-	}
-
-	protoMetadata, err := op.Metadata()
-	if err != nil {
-		return fmt.Errorf("Error while get disk create operation metadata: %s", err)
-	}
-
-	md, ok := protoMetadata.(*compute.CreateDiskMetadata)
-	if !ok {
-		return fmt.Errorf("could not get Disk ID from create operation metadata")
-	}
-
-	d.SetId(md.DiskId)
-
-	err = op.Wait(ctx)
-	if err != nil {
-		return fmt.Errorf("Error while waiting operation to create disk: %s", err)
-	}
-
-	if _, err := op.Response(); err != nil {
-		return fmt.Errorf("Disk creation failed: %s", err)
-	}
-
-	return resourceYandexComputeDiskRead(d, meta)
+	return &req, nil
 }
 
 func resourceYandexComputeDiskRead(d *schema.ResourceData, meta interface{}) error {
@@ -269,8 +308,11 @@ func resourceYandexComputeDiskRead(d *schema.ResourceData, meta interface{}) err
 	d.Set("type", disk.TypeId)
 	d.Set("size", toGigabytes(disk.Size))
 	d.Set("block_size", int(disk.BlockSize))
-	d.Set("image_id", disk.GetSourceImageId())
-	d.Set("snapshot_id", disk.GetSourceSnapshotId())
+	// Cloned and migrated disks are created from a temporary snapshot, which is deleted once the disk is created
+	if _, ok := d.GetOk("source_disk_id"); !ok {
+		d.Set("image_id", disk.GetSourceImageId())
+		d.Set("snapshot_id", disk.GetSourceSnapshotId())
+	}
 	d.Set("disk_placement_policy", diskPlacementPolicy)
 
 	if err := d.Set("product_ids", disk.ProductIds); err != nil {
@@ -281,6 +323,18 @@ func resourceYandexComputeDiskRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceYandexComputeDiskUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("zone") {
+		if !d.Get("allow_zone_migration").(bool) {
+			return fmt.Errorf("Changing the zone of a disk requires its migration. " +
+				"To acknowledge this action, please set allow_zone_migration = true in your config file.")
+		}
+		// The new disk is created with all the other properties from the config
+		if err := migrateComputeDisk(d, meta.(*Config)); err != nil {
+			return err
+		}
+		return resourceYandexComputeDiskRead(d, meta)
+	}
+
 	d.Partial(true)
 
 	folderPropName := "folder_id"
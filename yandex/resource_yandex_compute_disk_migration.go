@@ -0,0 +1,281 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/genproto/protobuf/field_mask"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+)
+
+// resourceYandexComputeDiskZoneCustomizeDiff recreates the disk on zone change, unless its migration is allowed.
+func resourceYandexComputeDiskZoneCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("zone") {
+		return nil
+	}
+	if !d.Get("allow_zone_migration").(bool) {
+		return d.ForceNew("zone")
+	}
+
+	// Disk is migrated to a new one in the target zone
+	for _, key := range []string{"status", "created_at", "product_ids"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// computeDiskAttachment keeps how the disk was attached to an instance to attach it back if the migration fails.
+type computeDiskAttachment struct {
+	instanceID string
+	spec       *compute.AttachedDiskSpec
+}
+
+// migrateComputeDisk moves the disk to the zone from the config: it detaches the disk from the instances, snapshots it,
+// creates a new disk from the snapshot in the target zone and only then deletes the old disk. If the new disk can't be
+// created or the old one can't be deleted, the old disk is attached back. The intermediate snapshot is always deleted.
+// The instances are in the old zone, so the new disk can't be attached to them and is left detached.
+func migrateComputeDisk(d *schema.ResourceData, config *Config) error {
+	ctx, cancel := context.WithTimeout(config.Context(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	oldDiskID := d.Id()
+	disk, err := config.sdk.Compute().Disk().Get(ctx, &compute.GetDiskRequest{
+		DiskId: oldDiskID,
+	})
+	if err != nil {
+		return fmt.Errorf("Error getting disk %q to migrate: %s", oldDiskID, err)
+	}
+
+	attachments, err := getComputeDiskAttachments(ctx, config, disk)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		if err := detachComputeDisk(config, attachment.instanceID, oldDiskID); err != nil {
+			return err
+		}
+	}
+	restoreAttachments := func() {
+		for _, attachment := range attachments {
+			if err := attachComputeDisk(config, attachment, oldDiskID); err != nil {
+				log.Printf("[WARN] Error attaching disk %q back to instance %q: %s", oldDiskID, attachment.instanceID, err)
+			}
+		}
+	}
+
+	snapshotID, err := createComputeDiskTemporarySnapshot(ctx, config, oldDiskID, disk.GetFolderId(),
+		fmt.Sprintf("Temporary snapshot to migrate disk %s to zone %s", oldDiskID, d.Get("zone").(string)))
+	if snapshotID != "" {
+		defer deleteComputeDiskTemporarySnapshot(config, snapshotID)
+	}
+	if err != nil {
+		restoreAttachments()
+		return err
+	}
+
+	req, err := prepareCreateDiskRequest(d, config)
+	if err != nil {
+		restoreAttachments()
+		return err
+	}
+	// The name is taken by the old disk until it is deleted
+	req.Name = ""
+	req.Source = &compute.CreateDiskRequest_SnapshotId{
+		SnapshotId: snapshotID,
+	}
+
+	log.Printf("[DEBUG] Creating disk in zone %q from snapshot %q", req.ZoneId, snapshotID)
+	op, err := config.sdk.WrapOperation(config.sdk.Compute().Disk().Create(ctx, req))
+	if err != nil {
+		restoreAttachments()
+		return fmt.Errorf("Error creating disk in zone %q to migrate disk %q: %s", req.ZoneId, oldDiskID, err)
+	}
+	protoMetadata, err := op.Metadata()
+	if err != nil {
+		restoreAttachments()
+		return fmt.Errorf("Error while get disk create operation metadata: %s", err)
+	}
+	md, ok := protoMetadata.(*compute.CreateDiskMetadata)
+	if !ok {
+		restoreAttachments()
+		return fmt.Errorf("could not get Disk ID from create operation metadata")
+	}
+	newDiskID := md.DiskId
+	if err := op.Wait(ctx); err != nil {
+		deleteComputeDisk(config, newDiskID)
+		restoreAttachments()
+		return fmt.Errorf("Error creating disk in zone %q to migrate disk %q: %s", req.ZoneId, oldDiskID, err)
+	}
+
+	log.Printf("[DEBUG] Deleting migrated disk %q", oldDiskID)
+	op, err = config.sdk.WrapOperation(config.sdk.Compute().Disk().Delete(ctx, &compute.DeleteDiskRequest{
+		DiskId: oldDiskID,
+	}))
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		deleteComputeDisk(config, newDiskID)
+		restoreAttachments()
+		return fmt.Errorf("Error deleting disk %q migrated to disk %q: %s", oldDiskID, newDiskID, err)
+	}
+
+	// The old disk is gone, the new one is managed from now on
+	d.SetId(newDiskID)
+	// A cloned disk keeps its configured source, so that it isn't recreated
+	if _, ok := d.GetOk("source_disk_id"); !ok {
+		d.Set("source_disk_id", oldDiskID)
+	}
+
+	if name := d.Get("name").(string); name != "" {
+		renameReq := &compute.UpdateDiskRequest{
+			DiskId: newDiskID,
+			Name:   name,
+			UpdateMask: &field_mask.FieldMask{
+				Paths: []string{"name"},
+			},
+		}
+		if err := makeDiskUpdateRequest(renameReq, d, config); err != nil {
+			return err
+		}
+	}
+
+	for _, attachment := range attachments {
+		log.Printf("[WARN] Disk %q migrated from disk %q is not attached to instance %q in another zone",
+			newDiskID, oldDiskID, attachment.instanceID)
+	}
+
+	return nil
+}
+
+func getComputeDiskAttachments(ctx context.Context, config *Config, disk *compute.Disk) ([]*computeDiskAttachment, error) {
+	var attachments []*computeDiskAttachment
+	for _, instanceID := range disk.GetInstanceIds() {
+		instance, err := config.sdk.Compute().Instance().Get(ctx, &compute.GetInstanceRequest{
+			InstanceId: instanceID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error getting instance %q with disk %q attached: %s", instanceID, disk.GetId(), err)
+		}
+
+		if instance.GetBootDisk().GetDiskId() == disk.GetId() {
+			return nil, fmt.Errorf("disk %q is the boot disk of instance %q and can't be migrated to another zone", disk.GetId(), instanceID)
+		}
+
+		for _, attached := range instance.GetSecondaryDisks() {
+			if attached.GetDiskId() != disk.GetId() {
+				continue
+			}
+			attachments = append(attachments, &computeDiskAttachment{
+				instanceID: instanceID,
+				spec: &compute.AttachedDiskSpec{
+					Mode:       compute.AttachedDiskSpec_Mode(attached.GetMode()),
+					DeviceName: attached.GetDeviceName(),
+					AutoDelete: attached.GetAutoDelete(),
+				},
+			})
+		}
+	}
+	return attachments, nil
+}
+
+func detachComputeDisk(config *Config, instanceID, diskID string) error {
+	req := &compute.DetachInstanceDiskRequest{
+		InstanceId: instanceID,
+		Disk: &compute.DetachInstanceDiskRequest_DiskId{
+			DiskId: diskID,
+		},
+	}
+	if err := makeDetachDiskRequest(req, config); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Successfully detached disk %s from instance %s", diskID, instanceID)
+	return nil
+}
+
+func attachComputeDisk(config *Config, attachment *computeDiskAttachment, diskID string) error {
+	req := &compute.AttachInstanceDiskRequest{
+		InstanceId: attachment.instanceID,
+		AttachedDiskSpec: &compute.AttachedDiskSpec{
+			Mode:       attachment.spec.Mode,
+			DeviceName: attachment.spec.DeviceName,
+			AutoDelete: attachment.spec.AutoDelete,
+			Disk: &compute.AttachedDiskSpec_DiskId{
+				DiskId: diskID,
+			},
+		},
+	}
+	if err := makeAttachDiskRequest(req, config); err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Successfully attached disk %s to instance %s", diskID, attachment.instanceID)
+	return nil
+}
+
+// createComputeDiskTemporarySnapshot creates a snapshot of the disk to create another disk from, e.g. in another zone.
+// The snapshot ID is returned even if the snapshot fails to be created, so that it can be deleted.
+func createComputeDiskTemporarySnapshot(ctx context.Context, config *Config, diskID, folderID, description string) (string, error) {
+	log.Printf("[DEBUG] Creating temporary snapshot of disk %q", diskID)
+
+	op, err := config.sdk.WrapOperation(config.sdk.Compute().Snapshot().Create(ctx, &compute.CreateSnapshotRequest{
+		FolderId:    folderID,
+		DiskId:      diskID,
+		Description: description,
+	}))
+	if err != nil {
+		return "", fmt.Errorf("Error while requesting API to create snapshot of disk %q: %s", diskID, err)
+	}
+
+	protoMetadata, err := op.Metadata()
+	if err != nil {
+		return "", fmt.Errorf("Error while get snapshot create operation metadata: %s", err)
+	}
+	md, ok := protoMetadata.(*compute.CreateSnapshotMetadata)
+	if !ok {
+		return "", fmt.Errorf("could not get Snapshot ID from create operation metadata")
+	}
+
+	if err := op.Wait(ctx); err != nil {
+		return md.GetSnapshotId(), fmt.Errorf("Error creating snapshot of disk %q: %s", diskID, err)
+	}
+
+	return md.GetSnapshotId(), nil
+}
+
+// deleteComputeDiskTemporarySnapshot deletes the snapshot even if the disk creation is cancelled.
+func deleteComputeDiskTemporarySnapshot(config *Config, snapshotID string) {
+	ctx, cancel := context.WithTimeout(config.Context(), yandexComputeDiskDefaultTimeout)
+	defer cancel()
+
+	log.Printf("[DEBUG] Deleting temporary snapshot %q", snapshotID)
+	op, err := config.sdk.WrapOperation(config.sdk.Compute().Snapshot().Delete(ctx, &compute.DeleteSnapshotRequest{
+		SnapshotId: snapshotID,
+	}))
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		log.Printf("[WARN] Error deleting temporary snapshot %q: %s", snapshotID, err)
+	}
+}
+
+func deleteComputeDisk(config *Config, diskID string) {
+	ctx, cancel := context.WithTimeout(config.Context(), yandexComputeDiskDefaultTimeout)
+	defer cancel()
+
+	op, err := config.sdk.WrapOperation(config.sdk.Compute().Disk().Delete(ctx, &compute.DeleteDiskRequest{
+		DiskId: diskID,
+	}))
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		log.Printf("[WARN] Error deleting disk %q: %s", diskID, err)
+	}
+}
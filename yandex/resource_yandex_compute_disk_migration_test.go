@@ -0,0 +1,288 @@
+package yandex
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func testComputeDiskZoneDiff(t *testing.T, allowZoneMigration bool) *terraform.InstanceDiff {
+	r := resourceYandexComputeDisk()
+
+	raw := map[string]interface{}{
+		"name": "disk",
+		"zone": "ru-central1-a",
+		"size": 10,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.SetId("disk1")
+
+	config := map[string]interface{}{
+		"name":                 "disk",
+		"zone":                 "ru-central1-b",
+		"size":                 10,
+		"allow_zone_migration": allowZoneMigration,
+	}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), &Config{})
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+
+	return diff
+}
+
+func TestComputeDiskZoneChangeRecreatesDisk(t *testing.T) {
+	diff := testComputeDiskZoneDiff(t, false)
+
+	assert.True(t, diff.RequiresNew())
+	assert.True(t, diff.Attributes["zone"].RequiresNew)
+}
+
+func TestComputeDiskZoneChangeMigratesDisk(t *testing.T) {
+	diff := testComputeDiskZoneDiff(t, true)
+
+	assert.False(t, diff.RequiresNew())
+	assert.Equal(t, "ru-central1-b", diff.Attributes["zone"].New)
+	assert.True(t, diff.Attributes["created_at"].NewComputed)
+}
+
+func TestMigrateComputeDisk(t *testing.T) {
+	prefix := []string{
+		"get disk old-disk",
+		"get instance",
+		"detach old-disk from instance",
+		"create snapshot of old-disk",
+		"create disk from snapshot",
+	}
+	testCases := []struct {
+		name          string
+		failed        string
+		expectedCalls []string
+		expectedID    string
+	}{
+		{
+			name:          "migrated",
+			expectedCalls: []string{"delete disk old-disk", "rename new-disk to disk", "delete snapshot"},
+			expectedID:    "new-disk",
+		},
+		{
+			name:          "disk failed",
+			failed:        "create disk from snapshot",
+			expectedCalls: []string{"delete disk new-disk", "attach old-disk to instance", "delete snapshot"},
+			expectedID:    "old-disk",
+		},
+		{
+			name:          "old disk not deleted",
+			failed:        "delete disk old-disk",
+			expectedCalls: []string{"delete disk old-disk", "delete disk new-disk", "attach old-disk to instance", "delete snapshot"},
+			expectedID:    "old-disk",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &diskMockServer{failed: tc.failed}
+			config := newDiskMockTestConfig(t, mock)
+
+			d := schema.TestResourceDataRaw(t, resourceYandexComputeDisk().Schema, map[string]interface{}{
+				"name":                 "disk",
+				"zone":                 "ru-central1-b",
+				"size":                 10,
+				"allow_zone_migration": true,
+			})
+			d.SetId("old-disk")
+			err := migrateComputeDisk(d, config)
+
+			assert.Equal(t, append(prefix, tc.expectedCalls...), mock.calls)
+			assert.Equal(t, tc.expectedID, d.Id())
+			if tc.failed != "" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "old-disk", d.Get("source_disk_id"))
+		})
+	}
+}
+
+func TestComputeDiskCloneCreate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failed        string
+		expectedCalls []string
+		expectedID    string
+	}{
+		{
+			name:          "created",
+			expectedCalls: []string{"create snapshot of source-disk", "create disk from snapshot", "get disk new-disk", "delete snapshot"},
+			expectedID:    "new-disk",
+		},
+		{
+			name:          "disk failed",
+			failed:        "create disk from snapshot",
+			expectedCalls: []string{"create snapshot of source-disk", "create disk from snapshot", "delete snapshot"},
+			expectedID:    "new-disk",
+		},
+		{
+			name:          "snapshot failed",
+			failed:        "create snapshot of source-disk",
+			expectedCalls: []string{"create snapshot of source-disk", "delete snapshot"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &diskMockServer{failed: tc.failed}
+			config := newDiskMockTestConfig(t, mock)
+
+			d := schema.TestResourceDataRaw(t, resourceYandexComputeDisk().Schema, map[string]interface{}{
+				"name":           "disk",
+				"zone":           "ru-central1-b",
+				"size":           10,
+				"source_disk_id": "source-disk",
+			})
+			err := resourceYandexComputeDiskCreate(d, config)
+
+			assert.Equal(t, tc.expectedCalls, mock.calls)
+			assert.Equal(t, tc.expectedID, d.Id())
+			if tc.failed != "" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			// The intermediate snapshot is not kept as the source of the disk
+			assert.Equal(t, "", d.Get("snapshot_id"))
+			assert.Equal(t, "ru-central1-b", d.Get("zone"))
+		})
+	}
+}
+
+func newDiskMockTestConfig(t *testing.T, mock *diskMockServer) *Config {
+	return newComputeTestConfig(t, func(s *grpc.Server) {
+		compute.RegisterDiskServiceServer(s, &diskMockServerDisk{diskMockServer: mock})
+		compute.RegisterSnapshotServiceServer(s, &diskMockServerSnapshot{diskMockServer: mock})
+		compute.RegisterInstanceServiceServer(s, &diskMockServerInstance{diskMockServer: mock})
+	})
+}
+
+// diskMockServer records calls of compute services, and fails the operation of the call named in failed.
+type diskMockServer struct {
+	mu     sync.Mutex
+	calls  []string
+	failed string
+}
+
+func (s *diskMockServer) record(call string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, call)
+}
+
+func (s *diskMockServer) call(call string, metadata, response proto.Message) (*operation.Operation, error) {
+	s.record(call)
+
+	op := &operation.Operation{Id: "operation", Done: true}
+	md, err := anypb.New(metadata)
+	if err != nil {
+		return nil, err
+	}
+	op.Metadata = md
+	if s.failed == call {
+		op.Result = &operation.Operation_Error{Error: &status.Status{Code: int32(codes.Internal), Message: call + " failed"}}
+		return op, nil
+	}
+	if response != nil {
+		resp, err := anypb.New(response)
+		if err != nil {
+			return nil, err
+		}
+		op.Result = &operation.Operation_Response{Response: resp}
+	}
+	return op, nil
+}
+
+type diskMockServerDisk struct {
+	compute.UnimplementedDiskServiceServer
+	*diskMockServer
+}
+
+func (s *diskMockServerDisk) Get(_ context.Context, r *compute.GetDiskRequest) (*compute.Disk, error) {
+	s.record("get disk " + r.GetDiskId())
+
+	disk := &compute.Disk{
+		Id:       r.GetDiskId(),
+		Name:     "disk",
+		ZoneId:   "ru-central1-b",
+		Size:     toBytes(10),
+		Source:   &compute.Disk_SourceSnapshotId{SourceSnapshotId: "snapshot"},
+		FolderId: testConfigFolder,
+
+		DiskPlacementPolicy: &compute.DiskPlacementPolicy{},
+	}
+	if r.GetDiskId() == "old-disk" {
+		disk.ZoneId = "ru-central1-a"
+		disk.InstanceIds = []string{"instance"}
+	}
+	return disk, nil
+}
+
+func (s *diskMockServerDisk) Create(_ context.Context, r *compute.CreateDiskRequest) (*operation.Operation, error) {
+	return s.call("create disk from "+r.GetSnapshotId(), &compute.CreateDiskMetadata{DiskId: "new-disk"}, &compute.Disk{Id: "new-disk"})
+}
+
+func (s *diskMockServerDisk) Delete(_ context.Context, r *compute.DeleteDiskRequest) (*operation.Operation, error) {
+	return s.call("delete disk "+r.GetDiskId(), &compute.DeleteDiskMetadata{DiskId: r.GetDiskId()}, nil)
+}
+
+func (s *diskMockServerDisk) Update(_ context.Context, r *compute.UpdateDiskRequest) (*operation.Operation, error) {
+	return s.call("rename "+r.GetDiskId()+" to "+r.GetName(), &compute.UpdateDiskMetadata{DiskId: r.GetDiskId()}, nil)
+}
+
+type diskMockServerSnapshot struct {
+	compute.UnimplementedSnapshotServiceServer
+	*diskMockServer
+}
+
+func (s *diskMockServerSnapshot) Create(_ context.Context, r *compute.CreateSnapshotRequest) (*operation.Operation, error) {
+	return s.call("create snapshot of "+r.GetDiskId(), &compute.CreateSnapshotMetadata{SnapshotId: "snapshot"}, &compute.Snapshot{Id: "snapshot"})
+}
+
+func (s *diskMockServerSnapshot) Delete(_ context.Context, r *compute.DeleteSnapshotRequest) (*operation.Operation, error) {
+	return s.call("delete "+r.GetSnapshotId(), &compute.DeleteSnapshotMetadata{SnapshotId: r.GetSnapshotId()}, nil)
+}
+
+type diskMockServerInstance struct {
+	compute.UnimplementedInstanceServiceServer
+	*diskMockServer
+}
+
+func (s *diskMockServerInstance) Get(_ context.Context, r *compute.GetInstanceRequest) (*compute.Instance, error) {
+	s.record("get " + r.GetInstanceId())
+
+	return &compute.Instance{
+		Id:             r.GetInstanceId(),
+		ZoneId:         "ru-central1-a",
+		BootDisk:       &compute.AttachedDisk{DiskId: "boot-disk"},
+		SecondaryDisks: []*compute.AttachedDisk{{DiskId: "old-disk", Mode: compute.AttachedDisk_READ_WRITE, DeviceName: "data"}},
+	}, nil
+}
+
+func (s *diskMockServerInstance) DetachDisk(_ context.Context, r *compute.DetachInstanceDiskRequest) (*operation.Operation, error) {
+	return s.call("detach "+r.GetDiskId()+" from "+r.GetInstanceId(), &compute.DetachInstanceDiskMetadata{InstanceId: r.GetInstanceId()}, nil)
+}
+
+func (s *diskMockServerInstance) AttachDisk(_ context.Context, r *compute.AttachInstanceDiskRequest) (*operation.Operation, error) {
+	return s.call("attach "+r.GetAttachedDiskSpec().GetDiskId()+" to "+r.GetInstanceId(), &compute.AttachInstanceDiskMetadata{InstanceId: r.GetInstanceId()}, nil)
+}
@@ -26,6 +26,8 @@ func resourceYandexComputeImage() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceYandexComputeImageCustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(yandexComputeImageDefaultTimeout),
 			Update: schema.DefaultTimeout(yandexComputeImageDefaultTimeout),
@@ -91,7 +93,7 @@ func resourceYandexComputeImage() *schema.Resource {
 				Computed:      true,
 				Optional:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"source_snapshot", "source_disk", "source_url", "source_image"},
+				ConflictsWith: []string{"source_snapshot", "source_disk", "source_url", "source_image", "source_file"},
 			},
 
 			"source_image": {
@@ -99,7 +101,7 @@ func resourceYandexComputeImage() *schema.Resource {
 				Computed:      true,
 				Optional:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"source_snapshot", "source_disk", "source_url", "source_family"},
+				ConflictsWith: []string{"source_snapshot", "source_disk", "source_url", "source_family", "source_file"},
 			},
 
 			"source_snapshot": {
@@ -107,7 +109,7 @@ func resourceYandexComputeImage() *schema.Resource {
 				Computed:      true,
 				Optional:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"source_image", "source_disk", "source_url", "source_family"},
+				ConflictsWith: []string{"source_image", "source_disk", "source_url", "source_family", "source_file"},
 			},
 
 			"source_disk": {
@@ -115,7 +117,7 @@ func resourceYandexComputeImage() *schema.Resource {
 				Computed:      true,
 				Optional:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"source_image", "source_snapshot", "source_url", "source_family"},
+				ConflictsWith: []string{"source_image", "source_snapshot", "source_url", "source_family", "source_file"},
 			},
 
 			"source_url": {
@@ -123,7 +125,66 @@ func resourceYandexComputeImage() *schema.Resource {
 				Computed:      true,
 				Optional:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"source_image", "source_snapshot", "source_disk", "source_family"},
+				ConflictsWith: []string{"source_image", "source_snapshot", "source_disk", "source_family", "source_file"},
+			},
+
+			"source_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"source_image", "source_snapshot", "source_disk", "source_url", "source_family"},
+				RequiredWith:  []string{"source_file_bucket"},
+			},
+
+			"source_file_bucket": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"source_file"},
+			},
+
+			"source_file_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"delete_source_file_object": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"source_file_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"source_file_format": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"source_file_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"source_file_modified_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"access_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"secret_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
 			},
 
 			"product_ids": {
@@ -188,7 +249,9 @@ func resourceYandexComputeImageCreate(d *schema.ResourceData, meta interface{})
 		},
 	}
 
-	err = prepareSourceForImage(&req, d, meta)
+	cleanup, err := prepareSourceForImage(&req, d, meta)
+	// The image is imported from the staging object of source_file while create operation is in progress
+	defer cleanup()
 	if err != nil {
 		return fmt.Errorf("Error while prepare request to create image: %s", err)
 	}
@@ -365,8 +428,10 @@ func resourceYandexComputeImageDelete(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
-func prepareSourceForImage(req *compute.CreateImageRequest, d *schema.ResourceData, meta interface{}) error {
-	sourceAttrs := []string{"source_family", "source_disk", "source_image", "source_snapshot", "source_url"}
+// prepareSourceForImage sets the source of the image to the request. cleanup must be called once the image is created.
+func prepareSourceForImage(req *compute.CreateImageRequest, d *schema.ResourceData, meta interface{}) (cleanup func(), err error) {
+	cleanup = func() {}
+	sourceAttrs := []string{"source_family", "source_disk", "source_image", "source_snapshot", "source_url", "source_file"}
 	var selectedSourceAttr string
 	var selectedSourceValue string
 
@@ -376,7 +441,7 @@ func prepareSourceForImage(req *compute.CreateImageRequest, d *schema.ResourceDa
 				selectedSourceAttr = attrName
 				selectedSourceValue = v.(string)
 			} else {
-				return fmt.Errorf("more than one source attribute present: %s and %s, only one allowed", selectedSourceAttr, attrName)
+				return cleanup, fmt.Errorf("more than one source attribute present: %s and %s, only one allowed", selectedSourceAttr, attrName)
 			}
 
 		}
@@ -392,7 +457,7 @@ func prepareSourceForImage(req *compute.CreateImageRequest, d *schema.ResourceDa
 			Family:   familyName,
 		})
 		if err != nil {
-			return fmt.Errorf("failed to find image with family \"%s\": %s", familyName, err)
+			return cleanup, fmt.Errorf("failed to find image with family \"%s\": %s", familyName, err)
 		}
 		req.Source = &compute.CreateImageRequest_ImageId{
 			ImageId: img.Id,
@@ -413,12 +478,22 @@ func prepareSourceForImage(req *compute.CreateImageRequest, d *schema.ResourceDa
 		req.Source = &compute.CreateImageRequest_Uri{
 			Uri: selectedSourceValue,
 		}
+	case "source_file":
+		config := meta.(*Config)
+		var uri string
+		uri, cleanup, err = uploadComputeImageSourceFile(config.Context(), d, config)
+		if err != nil {
+			return cleanup, err
+		}
+		req.Source = &compute.CreateImageRequest_Uri{
+			Uri: uri,
+		}
 	default:
 		// should not occur: validation must be done at Schema level
-		return fmt.Errorf("selected source attr %s not one from %s", selectedSourceAttr, sourceAttrs)
+		return cleanup, fmt.Errorf("selected source attr %s not one from %s", selectedSourceAttr, sourceAttrs)
 	}
 
-	return nil
+	return cleanup, nil
 }
 
 func makeImageUpdateRequest(req *compute.UpdateImageRequest, d *schema.ResourceData, meta interface{}) error {
@@ -0,0 +1,279 @@
+package yandex
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Formats of local image files, which Compute Cloud can create an image from.
+const (
+	computeImageFileFormatQCOW2 = "qcow2"
+	computeImageFileFormatVMDK  = "vmdk"
+	computeImageFileFormatVHD   = "vhd"
+	computeImageFileFormatVHDX  = "vhdx"
+	computeImageFileFormatRaw   = "raw"
+)
+
+// Presigned URL of the staging object should be valid until the image is created from it.
+const computeImageSourceFileURLMinExpiration = time.Hour
+
+// resourceYandexComputeImageCustomizeDiff computes hash of the local image file, so that the image is
+// recreated also when the content of the file changes at the same path. The file is hashed again only
+// when its size or modification time differs from the ones it was hashed with.
+func resourceYandexComputeImageCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	path, ok := d.GetOk("source_file")
+	if !ok {
+		return nil
+	}
+	if !d.NewValueKnown("source_file") {
+		if err := d.SetNewComputed("source_file_hash"); err != nil {
+			return err
+		}
+		if d.Id() == "" {
+			return nil
+		}
+		return d.ForceNew("source_file_hash")
+	}
+
+	size, modifiedAt, err := computeImageSourceFileStat(path.(string))
+	if err != nil {
+		return err
+	}
+	if d.Get("source_file_hash").(string) != "" && d.Get("source_file_size").(int) == int(size) &&
+		d.Get("source_file_modified_at").(string) == modifiedAt {
+		return nil
+	}
+
+	hash, err := computeImageSourceFileHash(path.(string))
+	if err != nil {
+		return err
+	}
+	// The file is only touched, so its new modification time isn't worth a diff
+	if d.Get("source_file_hash").(string) == hash {
+		return nil
+	}
+
+	format, err := detectComputeImageFileFormat(path.(string))
+	if err != nil {
+		return err
+	}
+	if err := d.SetNew("source_file_format", format); err != nil {
+		return err
+	}
+	if err := d.SetNew("source_file_hash", hash); err != nil {
+		return err
+	}
+	if err := d.SetNew("source_file_size", int(size)); err != nil {
+		return err
+	}
+	if err := d.SetNew("source_file_modified_at", modifiedAt); err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	return d.ForceNew("source_file_hash")
+}
+
+func computeImageSourceFileStat(path string) (size int64, modifiedAt string, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, "", fmt.Errorf("error reading image file %q: %s", path, err)
+	}
+	return info.Size(), info.ModTime().UTC().Format(time.RFC3339Nano), nil
+}
+
+// uploadComputeImageSourceFile uploads the local image file to the staging bucket and returns a presigned URL
+// of the object to create the image from. cleanup deletes the staging object, if it is requested, and must be
+// called once the image is created from it, even if an error is returned.
+func uploadComputeImageSourceFile(ctx context.Context, d *schema.ResourceData, config *Config) (url string, cleanup func(), err error) {
+	cleanup = func() {}
+
+	path := d.Get("source_file").(string)
+	bucket := d.Get("source_file_bucket").(string)
+	hash := d.Get("source_file_hash").(string)
+	if hash == "" {
+		// The path has not been known at plan time
+		size, modifiedAt, err := computeImageSourceFileStat(path)
+		if err != nil {
+			return "", cleanup, err
+		}
+		if hash, err = computeImageSourceFileHash(path); err != nil {
+			return "", cleanup, err
+		}
+		format, err := detectComputeImageFileFormat(path)
+		if err != nil {
+			return "", cleanup, err
+		}
+		d.Set("source_file_hash", hash)
+		d.Set("source_file_format", format)
+		d.Set("source_file_size", int(size))
+		d.Set("source_file_modified_at", modifiedAt)
+	}
+
+	key := d.Get("source_file_key").(string)
+	if key == "" {
+		key = computeImageSourceFileDefaultKey(path, hash)
+	}
+
+	s3Client, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return "", cleanup, fmt.Errorf("error getting storage client: %s", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", cleanup, fmt.Errorf("error opening image file %q: %s", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", cleanup, fmt.Errorf("error reading image file %q: %s", path, err)
+	}
+
+	log.Printf("[DEBUG] Uploading image file %q to bucket %q as %q", path, bucket, key)
+	uploader := s3manager.NewUploaderWithClient(s3Client, func(u *s3manager.Uploader) {
+		u.PartSize = computeImageSourceFilePartSize(info.Size())
+		u.LeavePartsOnError = false
+	})
+	_, err = uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        file,
+		ContentType: aws.String("application/octet-stream"),
+	})
+	if err != nil {
+		return "", cleanup, fmt.Errorf("error uploading image file %q to bucket %q: %s", path, bucket, err)
+	}
+
+	if d.Get("delete_source_file_object").(bool) {
+		cleanup = func() {
+			deleteComputeImageSourceFileObject(config.Context(), s3Client, bucket, key)
+		}
+	}
+
+	if err := d.Set("source_file_key", key); err != nil {
+		return "", cleanup, err
+	}
+
+	expiration := d.Timeout(schema.TimeoutCreate)
+	if expiration < computeImageSourceFileURLMinExpiration {
+		expiration = computeImageSourceFileURLMinExpiration
+	}
+	req, _ := s3Client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	url, err = req.Presign(expiration)
+	if err != nil {
+		return "", cleanup, fmt.Errorf("error presigning URL of object %q in bucket %q: %s", key, bucket, err)
+	}
+
+	return url, cleanup, nil
+}
+
+func deleteComputeImageSourceFileObject(ctx context.Context, s3Client *s3.S3, bucket, key string) {
+	log.Printf("[DEBUG] Deleting staging object %q in bucket %q", key, bucket)
+	_, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
+		return s3Client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+	})
+	if err != nil {
+		log.Printf("[WARN] error deleting staging object %q in bucket %q: %s", key, bucket, err)
+	}
+}
+
+// computeImageSourceFileDefaultKey makes key of the staging object unique for every content of the file.
+func computeImageSourceFileDefaultKey(path, hash string) string {
+	if len(hash) > 16 {
+		hash = hash[:16]
+	}
+	return hash + "/" + filepath.Base(path)
+}
+
+// computeImageSourceFilePartSize keeps the number of parts of large images below the S3 limit.
+func computeImageSourceFilePartSize(size int64) int64 {
+	partSize := size/s3manager.MaxUploadParts + 1
+	if partSize < s3manager.DefaultUploadPartSize {
+		return s3manager.DefaultUploadPartSize
+	}
+	return partSize
+}
+
+func computeImageSourceFileHash(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error opening image file %q: %s", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("error reading image file %q: %s", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// detectComputeImageFileFormat detects format of the image file by its magic numbers.
+// Files of unknown formats are considered raw disk images.
+func detectComputeImageFileFormat(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error opening image file %q: %s", path, err)
+	}
+	defer file.Close()
+
+	header := make([]byte, 512)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", fmt.Errorf("error reading image file %q: %s", path, err)
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte("QFI\xfb")):
+		return computeImageFileFormatQCOW2, nil
+	case bytes.HasPrefix(header, []byte("KDMV")),
+		bytes.HasPrefix(header, []byte("COWD")),
+		bytes.HasPrefix(header, []byte("# Disk DescriptorFile")):
+		return computeImageFileFormatVMDK, nil
+	case bytes.HasPrefix(header, []byte("vhdxfile")):
+		return computeImageFileFormatVHDX, nil
+	case bytes.HasPrefix(header, []byte("conectix")):
+		// Dynamic and differencing VHD images have a copy of the footer at the beginning
+		return computeImageFileFormatVHD, nil
+	}
+
+	// Fixed VHD images only have the footer at the last 512 bytes
+	info, err := file.Stat()
+	if err != nil {
+		return "", fmt.Errorf("error reading image file %q: %s", path, err)
+	}
+	if info.Size() >= 512 {
+		footer := make([]byte, 8)
+		if _, err := file.ReadAt(footer, info.Size()-512); err != nil {
+			return "", fmt.Errorf("error reading image file %q: %s", path, err)
+		}
+		if bytes.Equal(footer, []byte("conectix")) {
+			return computeImageFileFormatVHD, nil
+		}
+	}
+
+	return computeImageFileFormatRaw, nil
+}
@@ -0,0 +1,199 @@
+package yandex
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDetectComputeImageFileFormat(t *testing.T) {
+	fixedVHD := make([]byte, 2048)
+	copy(fixedVHD[len(fixedVHD)-512:], "conectix")
+
+	testCases := []struct {
+		name     string
+		content  []byte
+		expected string
+	}{
+		{name: "qcow2", content: []byte("QFI\xfb\x00\x00\x00\x03"), expected: computeImageFileFormatQCOW2},
+		{name: "vmdk sparse", content: []byte("KDMV\x01\x00\x00\x00"), expected: computeImageFileFormatVMDK},
+		{name: "vmdk descriptor", content: []byte("# Disk DescriptorFile\nversion=1\n"), expected: computeImageFileFormatVMDK},
+		{name: "vhdx", content: []byte("vhdxfile"), expected: computeImageFileFormatVHDX},
+		{name: "vhd dynamic", content: []byte("conectix\x00\x00\x00\x02"), expected: computeImageFileFormatVHD},
+		{name: "vhd fixed", content: fixedVHD, expected: computeImageFileFormatVHD},
+		{name: "raw", content: make([]byte, 4096), expected: computeImageFileFormatRaw},
+		{name: "empty", content: nil, expected: computeImageFileFormatRaw},
+	}
+
+	dir := t.TempDir()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, tc.name)
+			if err := os.WriteFile(path, tc.content, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			format, err := detectComputeImageFileFormat(path)
+			if err != nil {
+				t.Fatalf("failed to detect format: %s", err)
+			}
+			if format != tc.expected {
+				t.Fatalf("unexpected format: expected %q, got %q", tc.expected, format)
+			}
+		})
+	}
+}
+
+func TestComputeImageSourceFileHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.raw")
+	if err := os.WriteFile(path, []byte("image"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := computeImageSourceFileHash(path)
+	if err != nil {
+		t.Fatalf("failed to hash file: %s", err)
+	}
+	// echo -n "image" | sha256sum
+	if hash != "6105d6cc76af400325e94d588ce511be5bfdbb73b437dc51eca43917d7a43e3d" {
+		t.Fatalf("unexpected hash: %s", hash)
+	}
+	if key := computeImageSourceFileDefaultKey(path, hash); key != "6105d6cc76af4003/image.raw" {
+		t.Fatalf("unexpected key: %s", key)
+	}
+
+	if _, err := computeImageSourceFileHash(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatalf("expected error for missing file")
+	}
+}
+
+func TestComputeImageSourceFileDiff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.raw")
+	if err := os.WriteFile(path, []byte("image"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	size, modifiedAt, err := computeImageSourceFileStat(path)
+	if err != nil {
+		t.Fatalf("failed to stat file: %s", err)
+	}
+	const hash = "6105d6cc76af400325e94d588ce511be5bfdbb73b437dc51eca43917d7a43e3d"
+
+	r := resourceYandexComputeImage()
+	config := map[string]interface{}{
+		"source_file":        path,
+		"source_file_bucket": "bucket",
+	}
+	diff := func(stateHash string, stateModifiedAt string) *terraform.InstanceDiff {
+		d := schema.TestResourceDataRaw(t, r.Schema, config)
+		d.SetId("image")
+		d.Set("source_file_hash", stateHash)
+		d.Set("source_file_size", int(size))
+		d.Set("source_file_modified_at", stateModifiedAt)
+
+		diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+		if err != nil {
+			t.Fatalf("failed to diff: %s", err)
+		}
+		return diff
+	}
+
+	// The file isn't hashed again while its size and modification time are the same
+	if diff := diff("stale", modifiedAt); diff != nil && diff.RequiresNew() {
+		t.Errorf("image is recreated without changes of the file")
+	}
+	if diff := diff(hash, "2000-01-01T00:00:00Z"); diff != nil && diff.RequiresNew() {
+		t.Errorf("image is recreated when the file is only touched")
+	}
+	if diff := diff("stale", "2000-01-01T00:00:00Z"); diff == nil || !diff.RequiresNew() {
+		t.Errorf("image is not recreated when content of the file changes")
+	} else if diff.Attributes["source_file_hash"].New != hash {
+		t.Errorf("unexpected hash in diff: %s", diff.Attributes["source_file_hash"].New)
+	}
+
+	diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{"source_file": path}))
+	if !diags.HasError() {
+		t.Errorf("source_file is accepted without source_file_bucket")
+	}
+}
+
+func TestComputeImageSourceFilePartSize(t *testing.T) {
+	if size := computeImageSourceFilePartSize(1 << 20); size != s3manager.DefaultUploadPartSize {
+		t.Fatalf("unexpected part size of small file: %d", size)
+	}
+
+	const imageSize = 200 << 30
+	size := computeImageSourceFilePartSize(imageSize)
+	if parts := (imageSize + size - 1) / size; parts > s3manager.MaxUploadParts {
+		t.Fatalf("too many parts: %d", parts)
+	}
+}
+
+func TestUploadComputeImageSourceFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.raw")
+	if err := os.WriteFile(path, make([]byte, 4096), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, deleteObject := range map[string]bool{"delete": true, "keep": false} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				mu      sync.Mutex
+				objects = make(map[string]int)
+			)
+			s3Client := newTestStorageS3Client(t, map[string]string{}, func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch r.Method {
+				case http.MethodPut:
+					body, _ := io.ReadAll(r.Body)
+					objects[r.URL.Path] = len(body)
+				case http.MethodDelete:
+					delete(objects, r.URL.Path)
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					w.WriteHeader(http.StatusNotImplemented)
+				}
+			})
+			sess, err := session.NewSession(&s3Client.Config)
+			if err != nil {
+				t.Fatalf("failed to create storage session: %s", err)
+			}
+			config := &Config{defaultS3Session: sess, contextWithClientTraceID: context.Background()}
+
+			d := schema.TestResourceDataRaw(t, resourceYandexComputeImage().Schema, map[string]interface{}{
+				"source_file":               path,
+				"source_file_bucket":        "staging",
+				"source_file_key":           "images/image.raw",
+				"delete_source_file_object": deleteObject,
+			})
+			url, cleanup, err := uploadComputeImageSourceFile(context.Background(), d, config)
+			if err != nil {
+				t.Fatalf("failed to upload image file: %s", err)
+			}
+			if url == "" {
+				t.Errorf("presigned URL is empty")
+			}
+			if size := objects["/staging/images/image.raw"]; size != 4096 {
+				t.Fatalf("unexpected size of the uploaded object: %d", size)
+			}
+			if d.Get("source_file_format").(string) != computeImageFileFormatRaw {
+				t.Errorf("unexpected format: %q", d.Get("source_file_format"))
+			}
+
+			cleanup()
+			if _, ok := objects["/staging/images/image.raw"]; ok == deleteObject {
+				t.Fatalf("staging object is kept %t with delete_source_file_object %t", ok, deleteObject)
+			}
+		})
+	}
+}
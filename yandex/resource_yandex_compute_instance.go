@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/mitchellh/hashstructure"
@@ -45,6 +47,11 @@ func resourceYandexComputeInstance() *schema.Resource {
 
 		MigrateState: resourceComputeInstanceMigrateState,
 
+		CustomizeDiff: customdiff.All(
+			cloudInitCustomizeDiff(""),
+			resourceYandexComputeInstanceUpdatePlanCustomizeDiff,
+		),
+
 		Schema: map[string]*schema.Schema{
 			"resources": {
 				Type:     schema.TypeList,
@@ -384,6 +391,8 @@ func resourceYandexComputeInstance() *schema.Resource {
 				Set:      schema.HashString,
 			},
 
+			"cloud_init": cloudInitSchema(),
+
 			"platform_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -401,6 +410,18 @@ func resourceYandexComputeInstance() *schema.Resource {
 				Optional: true,
 			},
 
+			"wait_for_serial_output_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"planned_update_actions": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
 			"secondary_disk": {
 				Type:     schema.TypeSet,
 				Set:      hashInstanceSecondaryDisks,
@@ -656,6 +677,12 @@ func resourceYandexComputeInstanceCreate(d *schema.ResourceData, meta interface{
 		return fmt.Errorf("Instance creation failed: %s", err)
 	}
 
+	if v, ok := d.GetOk("wait_for_serial_output_regex"); ok {
+		if err := waitComputeInstanceSerialOutput(ctx, config, md.InstanceId, regexp.MustCompile(v.(string))); err != nil {
+			return err
+		}
+	}
+
 	return resourceYandexComputeInstanceRead(d, meta)
 }
 
@@ -727,7 +754,7 @@ func resourceYandexComputeInstanceRead(d *schema.ResourceData, meta interface{})
 	}
 	d.Set("hostname", hostname)
 
-	if err := d.Set("metadata", instance.Metadata); err != nil {
+	if err := d.Set("metadata", flattenMetadataWithCloudInit(instance.Metadata, d.Get("cloud_init"))); err != nil {
 		return err
 	}
 
@@ -795,6 +822,11 @@ func resourceYandexComputeInstanceRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	// Update actions are planned only for the next apply, and are not kept in the state after it
+	if err := d.Set("planned_update_actions", nil); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -874,11 +906,15 @@ func resourceYandexComputeInstanceUpdate(d *schema.ResourceData, meta interface{
 	}
 
 	metadataPropName := "metadata"
-	if d.HasChange(metadataPropName) {
+	if d.HasChange(metadataPropName) || d.HasChange("cloud_init") {
 		metadataProp, err := expandLabels(d.Get(metadataPropName))
 		if err != nil {
 			return err
 		}
+		metadataProp, err = expandMetadataWithCloudInit(metadataProp, d.Get("cloud_init"))
+		if err != nil {
+			return err
+		}
 
 		req := &compute.UpdateInstanceRequest{
 			InstanceId: d.Id(),
@@ -1398,6 +1434,10 @@ func prepareCreateInstanceRequest(d *schema.ResourceData, meta *Config) (*comput
 	if err != nil {
 		return nil, fmt.Errorf("Error expanding metadata while creating instance: %s", err)
 	}
+	metadata, err = expandMetadataWithCloudInit(metadata, d.Get("cloud_init"))
+	if err != nil {
+		return nil, fmt.Errorf("Error expanding cloud_init while creating instance: %s", err)
+	}
 
 	resourcesSpec, err := expandInstanceResourcesSpec(d)
 	if err != nil {
@@ -35,6 +35,8 @@ func resourceYandexComputeInstanceGroup() *schema.Resource {
 
 		SchemaVersion: 0,
 
+		CustomizeDiff: cloudInitCustomizeDiff("instance_template.0."),
+
 		Schema: map[string]*schema.Schema{
 			"service_account_id": {
 				Type:     schema.TypeString,
@@ -314,6 +316,8 @@ func resourceYandexComputeInstanceGroup() *schema.Resource {
 							Set:      schema.HashString,
 						},
 
+						"cloud_init": cloudInitSchema(),
+
 						"labels": {
 							Type:     schema.TypeMap,
 							Optional: true,
@@ -1147,6 +1151,10 @@ func flattenInstanceGroup(d *schema.ResourceData, instanceGroup *instancegroup.I
 	if err != nil {
 		return err
 	}
+	if cloudInit, ok := d.GetOk("instance_template.0.cloud_init"); ok {
+		template[0]["metadata"] = flattenMetadataWithCloudInit(instanceGroup.GetInstanceTemplate().GetMetadata(), cloudInit)
+		template[0]["cloud_init"] = cloudInit
+	}
 	if err := d.Set("instance_template", template); err != nil {
 		return err
 	}
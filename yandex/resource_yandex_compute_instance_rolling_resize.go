@@ -0,0 +1,529 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/apploadbalancer/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/loadbalancer/v1"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+const (
+	yandexComputeInstanceRollingResizeDefaultTimeout = 60 * time.Minute
+	yandexComputeInstanceRollingResizeHealthTimeout  = "10m"
+	yandexComputeInstanceRollingResizePollInterval   = 10 * time.Second
+	yandexComputeInstanceRollingResizeStatusInterval = 2 * time.Second
+)
+
+func resourceYandexComputeInstanceRollingResize() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceYandexComputeInstanceRollingResizeCreate,
+		ReadContext:   resourceYandexComputeInstanceRollingResizeRead,
+		UpdateContext: resourceYandexComputeInstanceRollingResizeUpdate,
+		DeleteContext: resourceYandexComputeInstanceRollingResizeDelete,
+
+		CustomizeDiff: resourceYandexComputeInstanceRollingResizeCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(yandexComputeInstanceRollingResizeDefaultTimeout),
+			Update: schema.DefaultTimeout(yandexComputeInstanceRollingResizeDefaultTimeout),
+		},
+
+		SchemaVersion: 0,
+
+		Schema: map[string]*schema.Schema{
+			"folder_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"instance_labels": {
+				Type:     schema.TypeMap,
+				Required: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"platform_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"resources": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"memory": {
+							Type:         schema.TypeFloat,
+							Required:     true,
+							ValidateFunc: FloatAtLeast(0.0),
+						},
+
+						"cores": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"gpus": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"core_fraction": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  100,
+						},
+					},
+				},
+			},
+
+			"health_check": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_load_balancer": {
+							Type:         schema.TypeList,
+							Optional:     true,
+							MaxItems:     1,
+							ExactlyOneOf: []string{"health_check.0.network_load_balancer", "health_check.0.application_load_balancer"},
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"network_load_balancer_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"target_group_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+
+						"application_load_balancer": {
+							Type:         schema.TypeList,
+							Optional:     true,
+							MaxItems:     1,
+							ExactlyOneOf: []string{"health_check.0.network_load_balancer", "health_check.0.application_load_balancer"},
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"load_balancer_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"backend_group_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"target_group_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+
+						"timeout": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      yandexComputeInstanceRollingResizeHealthTimeout,
+							ValidateFunc: validateParsableValue(parseDuration),
+						},
+					},
+				},
+			},
+
+			"instance_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"outdated_instance_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// resourceYandexComputeInstanceRollingResizeCustomizeDiff plans resizing of the instances, which resources
+// differ from the configured ones, including the instances labeled after the previous apply.
+func resourceYandexComputeInstanceRollingResizeCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	outdated := d.Get("outdated_instance_ids").([]interface{})
+	if len(outdated) == 0 && !d.HasChange("resources") && !d.HasChange("platform_id") {
+		return nil
+	}
+
+	return d.SetNew("outdated_instance_ids", []interface{}{})
+}
+
+func resourceYandexComputeInstanceRollingResizeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	folderID, err := getFolderID(d, config)
+	if err != nil {
+		return diag.Errorf("Error getting folder ID while resizing instances: %s", err)
+	}
+	d.Set("folder_id", folderID)
+	d.SetId(id.UniqueId())
+
+	if err := rollingResizeComputeInstances(ctx, d, config, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceYandexComputeInstanceRollingResizeRead(ctx, d, meta)
+}
+
+func resourceYandexComputeInstanceRollingResizeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	instances, err := listComputeInstancesByLabels(ctx, config, d.Get("folder_id").(string), d.Get("instance_labels").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	spec := expandComputeInstanceRollingResizeSpec(d)
+	platformID := d.Get("platform_id").(string)
+
+	instanceIDs := make([]string, 0, len(instances))
+	outdatedIDs := make([]string, 0)
+	for _, instance := range instances {
+		instanceIDs = append(instanceIDs, instance.GetId())
+		if computeInstanceNeedsResize(instance, spec, platformID) {
+			outdatedIDs = append(outdatedIDs, instance.GetId())
+		}
+	}
+
+	if err := d.Set("instance_ids", instanceIDs); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("outdated_instance_ids", outdatedIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceYandexComputeInstanceRollingResizeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	if err := rollingResizeComputeInstances(ctx, d, config, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceYandexComputeInstanceRollingResizeRead(ctx, d, meta)
+}
+
+func resourceYandexComputeInstanceRollingResizeDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing rolling resize %q from state, instances keep their resources", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// rollingResizeComputeInstances resizes the instances one at a time. Running instance is stopped, resized and
+// started again, and the next instance is not touched until it becomes healthy in the load balancer.
+func rollingResizeComputeInstances(ctx context.Context, d *schema.ResourceData, config *Config, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	instances, err := listComputeInstancesByLabels(ctx, config, d.Get("folder_id").(string), d.Get("instance_labels").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	spec := expandComputeInstanceRollingResizeSpec(d)
+	platformID := d.Get("platform_id").(string)
+
+	for _, instance := range instances {
+		if !computeInstanceNeedsResize(instance, spec, platformID) {
+			continue
+		}
+
+		running, err := resizeComputeInstance(ctx, config, instance, spec, platformID)
+		if err != nil {
+			return err
+		}
+
+		// Stopped instances are left stopped
+		if !running {
+			continue
+		}
+
+		if _, ok := d.GetOk("health_check"); ok {
+			if err := waitComputeInstanceHealthy(ctx, d, config, instance); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// resizeComputeInstance updates resources of the instance once it is running or stopped, and reports whether it was
+// running. Running instance is stopped for the update and is started again, even if the update fails.
+func resizeComputeInstance(ctx context.Context, config *Config, instance *compute.Instance, spec *compute.ResourcesSpec, platformID string) (running bool, err error) {
+	instance, err = waitComputeInstanceStable(ctx, config, instance.GetId())
+	if err != nil {
+		return false, err
+	}
+
+	running = instance.GetStatus() == compute.Instance_RUNNING
+	log.Printf("[DEBUG] Resizing instance %q, running: %t", instance.GetId(), running)
+
+	if running {
+		op, stopErr := config.sdk.WrapOperation(config.sdk.Compute().Instance().Stop(ctx, &compute.StopInstanceRequest{
+			InstanceId: instance.GetId(),
+		}))
+		if stopErr == nil {
+			stopErr = op.Wait(ctx)
+		}
+		if stopErr != nil {
+			return running, fmt.Errorf("Error stopping instance %q: %s", instance.GetId(), stopErr)
+		}
+
+		defer func() {
+			op, startErr := config.sdk.WrapOperation(config.sdk.Compute().Instance().Start(ctx, &compute.StartInstanceRequest{
+				InstanceId: instance.GetId(),
+			}))
+			if startErr == nil {
+				startErr = op.Wait(ctx)
+			}
+			if startErr == nil {
+				return
+			}
+			if err != nil {
+				err = fmt.Errorf("%s, and error starting instance %q again: %s", err, instance.GetId(), startErr)
+				return
+			}
+			err = fmt.Errorf("Error starting instance %q: %s", instance.GetId(), startErr)
+		}()
+	}
+
+	req := &compute.UpdateInstanceRequest{
+		InstanceId:    instance.GetId(),
+		ResourcesSpec: spec,
+		UpdateMask: &field_mask.FieldMask{
+			Paths: []string{"resources_spec"},
+		},
+	}
+	if platformID != "" && platformID != instance.GetPlatformId() {
+		req.PlatformId = platformID
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "platform_id")
+	}
+	op, err := config.sdk.WrapOperation(config.sdk.Compute().Instance().Update(ctx, req))
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		return running, fmt.Errorf("Error resizing instance %q: %s", instance.GetId(), err)
+	}
+
+	return running, nil
+}
+
+// waitComputeInstanceStable waits until the instance is running or stopped, so that it can be stopped and updated.
+func waitComputeInstanceStable(ctx context.Context, config *Config, instanceID string) (*compute.Instance, error) {
+	for {
+		instance, err := config.sdk.Compute().Instance().Get(ctx, &compute.GetInstanceRequest{
+			InstanceId: instanceID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error getting instance %q: %s", instanceID, err)
+		}
+
+		switch instance.GetStatus() {
+		case compute.Instance_RUNNING, compute.Instance_STOPPED:
+			return instance, nil
+		case compute.Instance_ERROR, compute.Instance_CRASHED, compute.Instance_DELETING:
+			return nil, fmt.Errorf("instance %q can't be resized in status %s", instanceID, instance.GetStatus())
+		}
+
+		log.Printf("[DEBUG] Waiting for instance %q in status %s to become running or stopped", instanceID, instance.GetStatus())
+		timer := time.NewTimer(yandexComputeInstanceRollingResizeStatusInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("Error waiting for instance %q to become running or stopped: %s", instanceID, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func listComputeInstancesByLabels(ctx context.Context, config *Config, folderID string, labels map[string]interface{}) ([]*compute.Instance, error) {
+	var instances []*compute.Instance
+
+	it := config.sdk.Compute().Instance().InstanceIterator(ctx, &compute.ListInstancesRequest{FolderId: folderID})
+	for it.Next() {
+		instance := it.Value()
+		if computeInstanceHasLabels(instance, labels) {
+			instances = append(instances, instance)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("Error listing instances in folder %q: %s", folderID, err)
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].GetId() < instances[j].GetId()
+	})
+
+	return instances, nil
+}
+
+func computeInstanceHasLabels(instance *compute.Instance, labels map[string]interface{}) bool {
+	for k, v := range labels {
+		if value, ok := instance.GetLabels()[k]; !ok || value != v.(string) {
+			return false
+		}
+	}
+	return true
+}
+
+func expandComputeInstanceRollingResizeSpec(d *schema.ResourceData) *compute.ResourcesSpec {
+	return &compute.ResourcesSpec{
+		Memory:       toBytesFromFloat(d.Get("resources.0.memory").(float64)),
+		Cores:        int64(d.Get("resources.0.cores").(int)),
+		CoreFraction: int64(d.Get("resources.0.core_fraction").(int)),
+		Gpus:         int64(d.Get("resources.0.gpus").(int)),
+	}
+}
+
+func computeInstanceNeedsResize(instance *compute.Instance, spec *compute.ResourcesSpec, platformID string) bool {
+	resources := instance.GetResources()
+	if resources.GetMemory() != spec.Memory || resources.GetCores() != spec.Cores ||
+		resources.GetCoreFraction() != spec.CoreFraction || resources.GetGpus() != spec.Gpus {
+		return true
+	}
+	return platformID != "" && platformID != instance.GetPlatformId()
+}
+
+func computeInstanceAddresses(instance *compute.Instance) map[string]bool {
+	addresses := make(map[string]bool)
+	for _, iface := range instance.GetNetworkInterfaces() {
+		if address := iface.GetPrimaryV4Address().GetAddress(); address != "" {
+			addresses[address] = true
+		}
+		if address := iface.GetPrimaryV6Address().GetAddress(); address != "" {
+			addresses[address] = true
+		}
+	}
+	return addresses
+}
+
+// waitComputeInstanceHealthy waits until all targets of the instance are healthy in the load balancer target group.
+func waitComputeInstanceHealthy(ctx context.Context, d *schema.ResourceData, config *Config, instance *compute.Instance) error {
+	timeout, err := parseDuration(d.Get("health_check.0.timeout").(string))
+	if err != nil {
+		return err
+	}
+
+	addresses := computeInstanceAddresses(instance)
+	if len(addresses) == 0 {
+		return fmt.Errorf("instance %q has no addresses to check its health", instance.GetId())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout.AsDuration())
+	defer cancel()
+
+	log.Printf("[DEBUG] Waiting for instance %q to become healthy", instance.GetId())
+	for {
+		healthy, err := computeInstanceTargetsHealthy(ctx, d, config, addresses)
+		if err != nil {
+			return fmt.Errorf("Error waiting for instance %q to become healthy, rolling resize is stopped: %s", instance.GetId(), err)
+		}
+		if healthy {
+			return nil
+		}
+
+		timer := time.NewTimer(yandexComputeInstanceRollingResizePollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("Error waiting for instance %q to become healthy, rolling resize is stopped: %s", instance.GetId(), ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func computeInstanceTargetsHealthy(ctx context.Context, d *schema.ResourceData, config *Config, addresses map[string]bool) (bool, error) {
+	if _, ok := d.GetOk("health_check.0.network_load_balancer"); ok {
+		nlbID := d.Get("health_check.0.network_load_balancer.0.network_load_balancer_id").(string)
+		resp, err := config.sdk.LoadBalancer().NetworkLoadBalancer().GetTargetStates(ctx, &loadbalancer.GetTargetStatesRequest{
+			NetworkLoadBalancerId: nlbID,
+			TargetGroupId:         d.Get("health_check.0.network_load_balancer.0.target_group_id").(string),
+		})
+		if err != nil {
+			return false, fmt.Errorf("Error getting target states of network load balancer %q: %s", nlbID, err)
+		}
+		return networkLoadBalancerTargetsHealthy(resp.GetTargetStates(), addresses), nil
+	}
+
+	albID := d.Get("health_check.0.application_load_balancer.0.load_balancer_id").(string)
+	resp, err := config.sdk.ApplicationLoadBalancer().LoadBalancer().GetTargetStates(ctx, &apploadbalancer.GetTargetStatesRequest{
+		LoadBalancerId: albID,
+		BackendGroupId: d.Get("health_check.0.application_load_balancer.0.backend_group_id").(string),
+		TargetGroupId:  d.Get("health_check.0.application_load_balancer.0.target_group_id").(string),
+	})
+	if err != nil {
+		return false, fmt.Errorf("Error getting target states of application load balancer %q: %s", albID, err)
+	}
+	return applicationLoadBalancerTargetsHealthy(resp.GetTargetStates(), addresses), nil
+}
+
+// networkLoadBalancerTargetsHealthy reports whether the instance is a target of the group, and all its targets are healthy.
+func networkLoadBalancerTargetsHealthy(states []*loadbalancer.TargetState, addresses map[string]bool) bool {
+	found := false
+	for _, state := range states {
+		if !addresses[state.GetAddress()] {
+			continue
+		}
+		if state.GetStatus() != loadbalancer.TargetState_HEALTHY {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// applicationLoadBalancerTargetsHealthy reports whether the instance is a target of the group, and all its targets
+// are healthy in all availability zones.
+func applicationLoadBalancerTargetsHealthy(states []*apploadbalancer.TargetState, addresses map[string]bool) bool {
+	found := false
+	for _, state := range states {
+		if !addresses[state.GetTarget().GetIpAddress()] {
+			continue
+		}
+		zoneStatuses := state.GetStatus().GetZoneStatuses()
+		if len(zoneStatuses) == 0 {
+			return false
+		}
+		for _, zoneStatus := range zoneStatuses {
+			if zoneStatus.GetStatus() != apploadbalancer.TargetState_HEALTHY {
+				return false
+			}
+		}
+		found = true
+	}
+	return found
+}
@@ -0,0 +1,251 @@
+package yandex
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/apploadbalancer/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/endpoint"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/loadbalancer/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestComputeInstanceNeedsResize(t *testing.T) {
+	instance := &compute.Instance{
+		PlatformId: "standard-v3",
+		Resources: &compute.Resources{
+			Memory:       toBytes(4),
+			Cores:        2,
+			CoreFraction: 100,
+		},
+	}
+
+	spec := &compute.ResourcesSpec{Memory: toBytes(4), Cores: 2, CoreFraction: 100}
+	assert.False(t, computeInstanceNeedsResize(instance, spec, ""))
+	assert.False(t, computeInstanceNeedsResize(instance, spec, "standard-v3"))
+	assert.True(t, computeInstanceNeedsResize(instance, spec, "standard-v2"))
+
+	spec = &compute.ResourcesSpec{Memory: toBytes(8), Cores: 2, CoreFraction: 100}
+	assert.True(t, computeInstanceNeedsResize(instance, spec, ""))
+
+	spec = &compute.ResourcesSpec{Memory: toBytes(4), Cores: 2, CoreFraction: 50}
+	assert.True(t, computeInstanceNeedsResize(instance, spec, ""))
+}
+
+func TestComputeInstanceHasLabels(t *testing.T) {
+	instance := &compute.Instance{
+		Labels: map[string]string{"role": "web", "env": "prod"},
+	}
+
+	assert.True(t, computeInstanceHasLabels(instance, map[string]interface{}{"role": "web"}))
+	assert.True(t, computeInstanceHasLabels(instance, map[string]interface{}{"role": "web", "env": "prod"}))
+	assert.False(t, computeInstanceHasLabels(instance, map[string]interface{}{"role": "db"}))
+	assert.False(t, computeInstanceHasLabels(instance, map[string]interface{}{"team": "web"}))
+}
+
+func TestNetworkLoadBalancerTargetsHealthy(t *testing.T) {
+	addresses := map[string]bool{"10.0.0.5": true}
+
+	assert.True(t, networkLoadBalancerTargetsHealthy([]*loadbalancer.TargetState{
+		{Address: "10.0.0.5", Status: loadbalancer.TargetState_HEALTHY},
+		{Address: "10.0.0.6", Status: loadbalancer.TargetState_UNHEALTHY},
+	}, addresses))
+
+	assert.False(t, networkLoadBalancerTargetsHealthy([]*loadbalancer.TargetState{
+		{Address: "10.0.0.5", Status: loadbalancer.TargetState_INITIAL},
+	}, addresses))
+
+	assert.False(t, networkLoadBalancerTargetsHealthy([]*loadbalancer.TargetState{
+		{Address: "10.0.0.6", Status: loadbalancer.TargetState_HEALTHY},
+	}, addresses), "instance is not a target of the group")
+}
+
+func TestApplicationLoadBalancerTargetsHealthy(t *testing.T) {
+	addresses := map[string]bool{"10.0.0.5": true}
+	target := func(address string, statuses ...apploadbalancer.TargetState_Status) *apploadbalancer.TargetState {
+		state := &apploadbalancer.TargetState{
+			Target: &apploadbalancer.Target{
+				AddressType: &apploadbalancer.Target_IpAddress{IpAddress: address},
+			},
+			Status: &apploadbalancer.TargetState_HealthcheckStatus{},
+		}
+		for _, status := range statuses {
+			state.Status.ZoneStatuses = append(state.Status.ZoneStatuses, &apploadbalancer.TargetState_ZoneHealthcheckStatus{
+				ZoneId: "ru-central1-a",
+				Status: status,
+			})
+		}
+		return state
+	}
+
+	assert.True(t, applicationLoadBalancerTargetsHealthy([]*apploadbalancer.TargetState{
+		target("10.0.0.5", apploadbalancer.TargetState_HEALTHY, apploadbalancer.TargetState_HEALTHY),
+	}, addresses))
+
+	assert.False(t, applicationLoadBalancerTargetsHealthy([]*apploadbalancer.TargetState{
+		target("10.0.0.5", apploadbalancer.TargetState_HEALTHY, apploadbalancer.TargetState_UNHEALTHY),
+	}, addresses))
+
+	assert.False(t, applicationLoadBalancerTargetsHealthy([]*apploadbalancer.TargetState{
+		target("10.0.0.5"),
+	}, addresses))
+
+	assert.False(t, applicationLoadBalancerTargetsHealthy([]*apploadbalancer.TargetState{
+		target("10.0.0.6", apploadbalancer.TargetState_HEALTHY),
+	}, addresses))
+}
+
+func TestResizeComputeInstance(t *testing.T) {
+	testCases := []struct {
+		name          string
+		statuses      []compute.Instance_Status
+		failed        map[string]bool
+		expectedCalls []string
+		expectedError []string
+		running       bool
+	}{
+		{
+			name:          "running",
+			statuses:      []compute.Instance_Status{compute.Instance_RUNNING},
+			expectedCalls: []string{"get", "stop", "update", "start"},
+			running:       true,
+		},
+		{
+			name:          "stopped",
+			statuses:      []compute.Instance_Status{compute.Instance_STOPPED},
+			expectedCalls: []string{"get", "update"},
+		},
+		{
+			name:          "stopping",
+			statuses:      []compute.Instance_Status{compute.Instance_STOPPING, compute.Instance_STOPPED},
+			expectedCalls: []string{"get", "get", "update"},
+		},
+		{
+			name:          "crashed",
+			statuses:      []compute.Instance_Status{compute.Instance_CRASHED},
+			expectedCalls: []string{"get"},
+			expectedError: []string{"can't be resized in status CRASHED"},
+		},
+		{
+			name:          "update failed",
+			statuses:      []compute.Instance_Status{compute.Instance_RUNNING},
+			failed:        map[string]bool{"update": true},
+			expectedCalls: []string{"get", "stop", "update", "start"},
+			expectedError: []string{"Error resizing instance"},
+			running:       true,
+		},
+		{
+			name:          "update and start failed",
+			statuses:      []compute.Instance_Status{compute.Instance_RUNNING},
+			failed:        map[string]bool{"update": true, "start": true},
+			expectedCalls: []string{"get", "stop", "update", "start"},
+			expectedError: []string{"Error resizing instance", "error starting instance"},
+			running:       true,
+		},
+		{
+			name:          "stop failed",
+			statuses:      []compute.Instance_Status{compute.Instance_RUNNING},
+			failed:        map[string]bool{"stop": true},
+			expectedCalls: []string{"get", "stop"},
+			expectedError: []string{"Error stopping instance"},
+			running:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			instances := &rollingResizeMockServerInstance{failed: tc.failed, statuses: tc.statuses}
+			config := newComputeTestConfig(t, func(s *grpc.Server) {
+				compute.RegisterInstanceServiceServer(s, instances)
+			})
+
+			instance := &compute.Instance{Id: "instance-id", Resources: &compute.Resources{Cores: 2}}
+			running, err := resizeComputeInstance(context.Background(), config, instance, &compute.ResourcesSpec{Cores: 4}, "")
+
+			assert.Equal(t, tc.expectedCalls, instances.calls)
+			assert.Equal(t, tc.running, running)
+			if len(tc.expectedError) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, expected := range tc.expectedError {
+				assert.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
+
+// newComputeTestConfig returns config of the provider, which calls compute services registered in a local gRPC server.
+func newComputeTestConfig(t *testing.T, register func(s *grpc.Server)) *Config {
+	grpcServer := grpc.NewServer()
+	l := localListener(t)
+
+	endpoint.RegisterApiEndpointServiceServer(grpcServer, &endpointsMockServerAPIEndpoint{endpoints: []*endpoint.ApiEndpoint{
+		{Id: "compute", Address: l.Addr().String()},
+	}})
+	register(grpcServer)
+
+	go func() { _ = grpcServer.Serve(l) }()
+	t.Cleanup(grpcServer.Stop)
+
+	config := &Config{
+		Endpoint:  l.Addr().String(),
+		FolderID:  testConfigFolder,
+		Token:     "t1.base.token",
+		Plaintext: true,
+	}
+	require.NoError(t, config.initAndValidate(context.Background(), testTerraformVersion, false))
+	return config
+}
+
+// rollingResizeMockServerInstance returns the instance in the next of statuses on each get, keeping the last one.
+type rollingResizeMockServerInstance struct {
+	compute.UnimplementedInstanceServiceServer
+	mu       sync.Mutex
+	calls    []string
+	failed   map[string]bool
+	statuses []compute.Instance_Status
+}
+
+func (s *rollingResizeMockServerInstance) Get(_ context.Context, r *compute.GetInstanceRequest) (*compute.Instance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, "get")
+	instance := &compute.Instance{Id: r.GetInstanceId(), Status: s.statuses[0], Resources: &compute.Resources{Cores: 2}}
+	if len(s.statuses) > 1 {
+		s.statuses = s.statuses[1:]
+	}
+	return instance, nil
+}
+
+func (s *rollingResizeMockServerInstance) call(name string) (*operation.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, name)
+	if s.failed[name] {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s failed", name)
+	}
+	return &operation.Operation{Id: name + "-operation", Done: true}, nil
+}
+
+func (s *rollingResizeMockServerInstance) Stop(context.Context, *compute.StopInstanceRequest) (*operation.Operation, error) {
+	return s.call("stop")
+}
+
+func (s *rollingResizeMockServerInstance) Update(context.Context, *compute.UpdateInstanceRequest) (*operation.Operation, error) {
+	return s.call("update")
+}
+
+func (s *rollingResizeMockServerInstance) Start(context.Context, *compute.StartInstanceRequest) (*operation.Operation, error) {
+	return s.call("start")
+}
@@ -0,0 +1,200 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Kinds of updates of a compute instance, as shown in planned_update_actions.
+const (
+	computeInstanceUpdateActionHot     = "hot"
+	computeInstanceUpdateActionStop    = "stop"
+	computeInstanceUpdateActionReplace = "replace"
+)
+
+// Properties, which are updated on a running instance.
+var computeInstanceHotUpdateProperties = []string{
+	"name",
+	"description",
+	"labels",
+	"metadata",
+	"cloud_init",
+	"metadata_options",
+	"service_account_id",
+	"maintenance_policy",
+	"maintenance_grace_period",
+	"secondary_disk",
+}
+
+// Properties, which can only be updated on a stopped instance.
+var computeInstanceStopUpdateProperties = []string{
+	"resources",
+	"platform_id",
+	"network_acceleration_type",
+	"scheduling_policy",
+	"placement_policy",
+	"filesystem",
+}
+
+// Properties, which changes force replacement of the instance.
+var computeInstanceReplaceUpdateProperties = []string{
+	"boot_disk",
+	"zone",
+	"hostname",
+	"local_disk",
+	"gpu_cluster_id",
+}
+
+// resourceYandexComputeInstanceUpdatePlanCustomizeDiff shows in planned_update_actions how every changed property
+// of an existing instance is going to be updated, and fails the plan if the update requires stopping the instance,
+// but it is not allowed.
+func resourceYandexComputeInstanceUpdatePlanCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	actions, err := planComputeInstanceUpdate(d)
+	if err != nil {
+		return err
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+
+	var stopProperties []string
+	replace := false
+	for property, action := range actions {
+		switch action {
+		case computeInstanceUpdateActionStop:
+			stopProperties = append(stopProperties, property)
+		case computeInstanceUpdateActionReplace:
+			replace = true
+		}
+	}
+	sort.Strings(stopProperties)
+
+	// Replaced instance is not stopped
+	if len(stopProperties) > 0 && !replace {
+		log.Printf("[WARN] Instance %q will be stopped to update %s", d.Id(), strings.Join(stopProperties, ", "))
+		if !d.Get("allow_stopping_for_update").(bool) {
+			return fmt.Errorf("Changing the %s in an instance requires stopping it. "+
+				"To acknowledge this action, please set allow_stopping_for_update = true in your config file.",
+				strings.Join(stopProperties, ", "))
+		}
+	}
+
+	return d.SetNew("planned_update_actions", actions)
+}
+
+// planComputeInstanceUpdate maps every changed property of the instance to the kind of its update.
+func planComputeInstanceUpdate(d *schema.ResourceDiff) (map[string]interface{}, error) {
+	actions := make(map[string]interface{})
+	changed := func(property string) bool {
+		if d.NewValueKnown(property) {
+			return d.HasChange(property)
+		}
+		// Optional computed properties omitted in config are unknown until the instance is read
+		o, _ := d.GetChange(property)
+		v := reflect.ValueOf(o)
+		switch v.Kind() {
+		case reflect.Slice, reflect.Map:
+			return v.Len() > 0
+		case reflect.Invalid:
+			return false
+		default:
+			return !v.IsZero()
+		}
+	}
+
+	for _, property := range computeInstanceHotUpdateProperties {
+		if changed(property) {
+			actions[property] = computeInstanceUpdateActionHot
+		}
+	}
+	for _, property := range computeInstanceStopUpdateProperties {
+		if changed(property) {
+			actions[property] = computeInstanceUpdateActionStop
+		}
+	}
+	for _, property := range computeInstanceReplaceUpdateProperties {
+		if changed(property) {
+			actions[property] = computeInstanceUpdateActionReplace
+		}
+	}
+
+	if changed("folder_id") {
+		if d.Get("allow_recreate").(bool) {
+			// Instance is deleted and created in the new folder by update instead of moving it
+			actions["folder_id"] = computeInstanceUpdateActionReplace
+		} else {
+			actions["folder_id"] = computeInstanceUpdateActionStop
+		}
+	}
+
+	if changed("network_interface") {
+		o, n := d.GetChange("network_interface")
+		stop, err := networkInterfacesUpdateNeedsStop(o.([]interface{}), n.([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		if stop {
+			actions["network_interface"] = computeInstanceUpdateActionStop
+		} else {
+			actions["network_interface"] = computeInstanceUpdateActionHot
+		}
+	}
+
+	return actions, nil
+}
+
+// networkInterfacesUpdateNeedsStop follows the rules of getSpecsForUpdateNetworkInterfaces
+// to find out whether the instance is stopped to update its network interfaces.
+func networkInterfacesUpdateNeedsStop(oldList, newList []interface{}) (bool, error) {
+	if len(oldList) != len(newList) {
+		return true, nil
+	}
+
+	for i := range oldList {
+		oldIface, _ := oldList[i].(map[string]interface{})
+		newIface, _ := newList[i].(map[string]interface{})
+		if oldIface == nil || newIface == nil {
+			return true, nil
+		}
+
+		if oldIface["subnet_id"] != newIface["subnet_id"] {
+			return true, nil
+		}
+
+		oldV4Spec, err := expandPrimaryV4AddressSpec(oldIface)
+		if err != nil {
+			return false, err
+		}
+		newV4Spec, err := expandPrimaryV4AddressSpec(newIface)
+		if err != nil {
+			return false, err
+		}
+		if needToRestartDueToAddressChange(oldV4Spec, newV4Spec) {
+			return true, nil
+		}
+
+		oldV6Spec, err := expandPrimaryV6AddressSpec(oldIface)
+		if err != nil {
+			return false, err
+		}
+		newV6Spec, err := expandPrimaryV6AddressSpec(newIface)
+		if err != nil {
+			return false, err
+		}
+		if needToRestartDueToAddressChange(oldV6Spec, newV6Spec) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
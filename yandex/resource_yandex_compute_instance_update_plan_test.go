@@ -0,0 +1,181 @@
+package yandex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testComputeInstanceUpdatePlanConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name":        "instance",
+		"zone":        "ru-central1-a",
+		"folder_id":   "folder1",
+		"platform_id": "standard-v3",
+		"resources": []interface{}{
+			map[string]interface{}{
+				"cores":  2,
+				"memory": 4,
+			},
+		},
+		"boot_disk": []interface{}{
+			map[string]interface{}{
+				"disk_id": "disk1",
+			},
+		},
+		"network_interface": []interface{}{
+			map[string]interface{}{
+				"subnet_id": "subnet1",
+				"nat":       false,
+			},
+		},
+		"labels": map[string]interface{}{
+			"env": "test",
+		},
+	}
+}
+
+func testComputeInstanceUpdatePlanDiff(t *testing.T, update func(config map[string]interface{})) (*terraform.InstanceDiff, error) {
+	r := resourceYandexComputeInstance()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, testComputeInstanceUpdatePlanConfig())
+	d.SetId("instance1")
+	state := d.State()
+
+	config := testComputeInstanceUpdatePlanConfig()
+	update(config)
+
+	return r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), &Config{})
+}
+
+func TestComputeInstanceUpdatePlanHot(t *testing.T) {
+	diff, err := testComputeInstanceUpdatePlanDiff(t, func(config map[string]interface{}) {
+		config["labels"] = map[string]interface{}{"env": "prod"}
+		config["description"] = "updated"
+	})
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+
+	assert.False(t, diff.RequiresNew())
+	assert.Equal(t, computeInstanceUpdateActionHot, diff.Attributes["planned_update_actions.labels"].New)
+	assert.Equal(t, computeInstanceUpdateActionHot, diff.Attributes["planned_update_actions.description"].New)
+	assert.NotContains(t, diff.Attributes, "planned_update_actions.resources")
+}
+
+func TestComputeInstanceUpdatePlanStop(t *testing.T) {
+	resize := func(config map[string]interface{}) {
+		config["resources"] = []interface{}{
+			map[string]interface{}{
+				"cores":  4,
+				"memory": 8,
+			},
+		}
+	}
+
+	_, err := testComputeInstanceUpdatePlanDiff(t, resize)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "allow_stopping_for_update")
+
+	diff, err := testComputeInstanceUpdatePlanDiff(t, func(config map[string]interface{}) {
+		resize(config)
+		config["allow_stopping_for_update"] = true
+	})
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+
+	assert.False(t, diff.RequiresNew())
+	assert.Equal(t, computeInstanceUpdateActionStop, diff.Attributes["planned_update_actions.resources"].New)
+}
+
+func TestComputeInstanceUpdatePlanReplace(t *testing.T) {
+	diff, err := testComputeInstanceUpdatePlanDiff(t, func(config map[string]interface{}) {
+		config["zone"] = "ru-central1-b"
+		config["platform_id"] = "standard-v2"
+	})
+	require.NoError(t, err, "stopping is not required, since the instance is replaced")
+	require.NotNil(t, diff)
+
+	assert.True(t, diff.RequiresNew())
+}
+
+func TestComputeInstanceUpdatePlanFolder(t *testing.T) {
+	diff, err := testComputeInstanceUpdatePlanDiff(t, func(config map[string]interface{}) {
+		config["folder_id"] = "folder2"
+		config["allow_stopping_for_update"] = true
+	})
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.False(t, diff.RequiresNew())
+	assert.Equal(t, computeInstanceUpdateActionStop, diff.Attributes["planned_update_actions.folder_id"].New)
+
+	diff, err = testComputeInstanceUpdatePlanDiff(t, func(config map[string]interface{}) {
+		config["folder_id"] = "folder2"
+		config["allow_recreate"] = true
+	})
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	// Instance is recreated in the new folder by update
+	assert.False(t, diff.RequiresNew())
+	assert.Equal(t, computeInstanceUpdateActionReplace, diff.Attributes["planned_update_actions.folder_id"].New)
+}
+
+func TestNetworkInterfacesUpdateNeedsStop(t *testing.T) {
+	iface := func(subnetID, ipAddress string, nat bool) map[string]interface{} {
+		return map[string]interface{}{
+			"subnet_id":          subnetID,
+			"ipv4":               true,
+			"ip_address":         ipAddress,
+			"ipv6":               false,
+			"ipv6_address":       "",
+			"nat":                nat,
+			"nat_ip_address":     "",
+			"security_group_ids": schema.NewSet(schema.HashString, nil),
+			"dns_record":         []interface{}{},
+			"ipv6_dns_record":    []interface{}{},
+			"nat_dns_record":     []interface{}{},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		old, new []interface{}
+		stop     bool
+	}{
+		{
+			name: "nat",
+			old:  []interface{}{iface("subnet1", "10.0.0.5", false)},
+			new:  []interface{}{iface("subnet1", "10.0.0.5", true)},
+			stop: false,
+		},
+		{
+			name: "subnet",
+			old:  []interface{}{iface("subnet1", "10.0.0.5", false)},
+			new:  []interface{}{iface("subnet2", "", false)},
+			stop: true,
+		},
+		{
+			name: "address",
+			old:  []interface{}{iface("subnet1", "10.0.0.5", false)},
+			new:  []interface{}{iface("subnet1", "10.0.0.6", false)},
+			stop: true,
+		},
+		{
+			name: "attach",
+			old:  []interface{}{iface("subnet1", "10.0.0.5", false)},
+			new:  []interface{}{iface("subnet1", "10.0.0.5", false), iface("subnet2", "", false)},
+			stop: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			stop, err := networkInterfacesUpdateNeedsStop(tc.old, tc.new)
+			require.NoError(t, err)
+			assert.Equal(t, tc.stop, stop)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -20,6 +21,7 @@ func resourceYandexKMSSecretCiphertext() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceYandexKMSSecretCiphertextCreate,
 		Read:   resourceYandexKMSSecretCiphertextRead,
+		Update: resourceYandexKMSSecretCiphertextUpdate,
 		Delete: resourceYandexKMSSecretCiphertextDelete,
 
 		Timeouts: &schema.ResourceTimeout{
@@ -45,9 +47,26 @@ func resourceYandexKMSSecretCiphertext() *schema.Resource {
 			"plaintext": {
 				Type:         schema.TypeString,
 				ValidateFunc: validation.StringLenBetween(0, 32768),
-				Required:     true,
+				Optional:     true,
 				ForceNew:     true,
 				Sensitive:    true,
+				ExactlyOneOf: []string{"plaintext", "plaintext_wo"},
+			},
+
+			"plaintext_wo": {
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringLenBetween(0, 32768),
+				Optional:     true,
+				WriteOnly:    true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"plaintext", "plaintext_wo"},
+			},
+
+			"plaintext_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"plaintext_wo"},
 			},
 
 			"ciphertext": {
@@ -64,9 +83,18 @@ func resourceYandexKMSSecretCiphertextCreate(d *schema.ResourceData, meta interf
 	ctx, cancel := config.ContextWithTimeout(d.Timeout(schema.TimeoutCreate))
 	defer cancel()
 
+	plaintext := d.Get("plaintext").(string)
+	if plaintext == "" {
+		var err error
+		plaintext, err = getWriteOnlyString(d, cty.GetAttrPath("plaintext_wo"))
+		if err != nil {
+			return err
+		}
+	}
+
 	req := &kms.SymmetricEncryptRequest{
 		KeyId:      d.Get("key_id").(string),
-		Plaintext:  []byte(d.Get("plaintext").(string)),
+		Plaintext:  []byte(plaintext),
 		AadContext: []byte(d.Get("aad_context").(string)),
 	}
 
@@ -118,6 +146,12 @@ func resourceYandexKMSSecretCiphertextRead(d *schema.ResourceData, meta interfac
 	return nil
 }
 
+// All attributes but write-only plaintext_wo force a new resource. Changes of plaintext_wo are not persisted,
+// and re-encryption is triggered by bumping plaintext_wo_version, so there is nothing to update in place.
+func resourceYandexKMSSecretCiphertextUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceYandexKMSSecretCiphertextRead(d, meta)
+}
+
 func resourceYandexKMSSecretCiphertextDelete(d *schema.ResourceData, meta interface{}) error {
 	d.SetId("")
 
@@ -3,6 +3,7 @@ package yandex
 import (
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 	"time"
 
@@ -36,6 +37,7 @@ func resourceYandexKubernetesNodeGroup() *schema.Resource {
 			Update: schema.DefaultTimeout(yandexKubernetesNodeGroupUpdateTimeout),
 			Delete: schema.DefaultTimeout(yandexKubernetesNodeGroupDeleteTimeout),
 		},
+		CustomizeDiff: cloudInitCustomizeDiff("instance_template.0."),
 		Schema: map[string]*schema.Schema{
 			"cluster_id": {
 				Type:     schema.TypeString,
@@ -223,6 +225,7 @@ func resourceYandexKubernetesNodeGroup() *schema.Resource {
 							Elem:     &schema.Schema{Type: schema.TypeString},
 							Set:      schema.HashString,
 						},
+						"cloud_init": cloudInitSchema(),
 						"scheduling_policy": {
 							Type:     schema.TypeList,
 							MaxItems: 1,
@@ -842,6 +845,10 @@ func getNodeGroupTemplate(d *schema.ResourceData) (*k8s.NodeTemplate, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error expanding metadata while creating Kubernetes node group: %s", err)
 	}
+	metadata, err = expandMetadataWithCloudInit(metadata, h.Get("cloud_init"))
+	if err != nil {
+		return nil, fmt.Errorf("error expanding cloud_init while creating Kubernetes node group: %s", err)
+	}
 
 	labels, err := expandLabels(h.Get("labels"))
 	if err != nil {
@@ -1053,6 +1060,10 @@ func flattenNodeGroupSchemaData(ng *k8s.NodeGroup, d *schema.ResourceData) error
 	}
 
 	tpl := flattenKubernetesNodeGroupTemplate(ng.GetNodeTemplate())
+	if cloudInit, ok := d.GetOk("instance_template.0.cloud_init"); ok {
+		tpl[0]["metadata"] = flattenMetadataWithCloudInit(ng.GetNodeTemplate().GetMetadata(), cloudInit)
+		tpl[0]["cloud_init"] = cloudInit
+	}
 	if err := d.Set("instance_template", tpl); err != nil {
 		return err
 	}
@@ -1131,6 +1142,7 @@ var nodeGroupUpdateFieldsMap = map[string]string{
 	"node_labels":                                               "node_labels",
 	"instance_template.0.platform_id":                           "node_template.platform_id",
 	"instance_template.0.metadata":                              "node_template.metadata",
+	"instance_template.0.cloud_init":                            "node_template.metadata",
 	"instance_template.0.resources.0.memory":                    "node_template.resources_spec.memory",
 	"instance_template.0.resources.0.cores":                     "node_template.resources_spec.cores",
 	"instance_template.0.resources.0.gpus":                      "node_template.resources_spec.gpus",
@@ -1167,7 +1179,8 @@ func resourceYandexKubernetesNodeGroupUpdate(d *schema.ResourceData, meta interf
 
 	var updatePath []string
 	for field, path := range nodeGroupUpdateFieldsMap {
-		if d.HasChange(field) {
+		// Several fields, e.g. metadata and cloud_init, are updated by the same path
+		if d.HasChange(field) && !slices.Contains(updatePath, path) {
 			updatePath = append(updatePath, path)
 		}
 	}
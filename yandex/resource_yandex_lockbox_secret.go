@@ -87,6 +87,67 @@ func resourceYandexLockboxSecret() *schema.Resource {
 				ValidateFunc: validation.StringLenBetween(0, 100),
 			},
 
+			"password_payload_specification": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"password_key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.All(validation.StringMatch(regexp.MustCompile(`^([-_./\\@0-9a-zA-Z]+)$`), ""), validation.StringLenBetween(0, 256)),
+						},
+
+						// Lockbox decides the length itself, if it is not set
+						"length": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+
+						"include_uppercase": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"include_lowercase": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"include_digits": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"include_punctuation": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"included_punctuation": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ValidateFunc:  validation.StringLenBetween(0, 32),
+							ConflictsWith: []string{"password_payload_specification.0.excluded_punctuation"},
+						},
+
+						"excluded_punctuation": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ValidateFunc:  validation.StringLenBetween(0, 31),
+							ConflictsWith: []string{"password_payload_specification.0.included_punctuation"},
+						},
+					},
+				},
+			},
+
 			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -111,6 +172,9 @@ func resourceYandexLockboxSecretCreate(ctx context.Context, d *schema.ResourceDa
 		KmsKeyId:           d.Get("kms_key_id").(string),
 		DeletionProtection: d.Get("deletion_protection").(bool),
 	}
+	if spec := expandLockboxPasswordPayloadSpecification(d); spec != nil {
+		req.SetPasswordPayloadSpecification(spec)
+	}
 
 	log.Printf("[INFO] creating Lockbox secret: %s", protojson.Format(req))
 
@@ -214,6 +278,10 @@ func yandexLockboxSecretRead(id string, isDataSource bool, ctx context.Context,
 		log.Printf("[ERROR] failed set field status: %s", err)
 		return diag.FromErr(err)
 	}
+	if err := d.Set("password_payload_specification", flattenLockboxPasswordPayloadSpecification(secret.GetPasswordPayloadSpecification())); err != nil {
+		log.Printf("[ERROR] failed set field password_payload_specification: %s", err)
+		return diag.FromErr(err)
+	}
 
 	log.Printf("[INFO] read Lockbox secret with ID: %s", d.Id())
 
@@ -233,6 +301,9 @@ func resourceYandexLockboxSecretUpdate(ctx context.Context, d *schema.ResourceDa
 			Paths: generateFieldMasks(d, resourceYandexLockboxSecretUpdateFieldsMap),
 		},
 	}
+	if spec := expandLockboxPasswordPayloadSpecification(d); spec != nil {
+		req.SetPasswordPayloadSpecification(spec)
+	}
 
 	log.Printf("[INFO] updating Lockbox secret: %s", protojson.Format(req))
 
@@ -286,8 +357,9 @@ func resourceYandexLockboxSecretDelete(ctx context.Context, d *schema.ResourceDa
 }
 
 var resourceYandexLockboxSecretUpdateFieldsMap = map[string]string{
-	"name":                "name",
-	"description":         "description",
-	"labels":              "labels",
-	"deletion_protection": "deletion_protection",
+	"name":                           "name",
+	"description":                    "description",
+	"labels":                         "labels",
+	"deletion_protection":            "deletion_protection",
+	"password_payload_specification": "password_payload_specification",
 }
@@ -53,6 +53,14 @@ func resourceYandexLockboxSecretVersion() *schema.Resource {
 							ValidateFunc: validation.StringLenBetween(0, 65536),
 						},
 
+						"text_value_wo": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							WriteOnly:    true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringLenBetween(0, 65536),
+						},
+
 						"command": {
 							Type:     schema.TypeList,
 							MaxItems: 1,
@@ -83,7 +91,8 @@ func resourceYandexLockboxSecretVersion() *schema.Resource {
 					},
 				},
 				ForceNew: true,
-				Required: true,
+				// Entries are omitted for secrets with password_payload_specification, which are generated by Lockbox
+				Optional: true,
 			},
 
 			"secret_id": {
@@ -99,6 +108,13 @@ func resourceYandexLockboxSecretVersion() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validation.StringLenBetween(0, 1024),
 			},
+
+			// Write-only values are not stored in state, so bumping this version is the only way to add a version with new ones.
+			"entries_wo_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
 		},
 	}
 }
@@ -111,6 +127,25 @@ func resourceYandexLockboxSecretVersionCreate(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
+	if len(versionPayloadEntries) == 0 {
+		secretID := d.Get("secret_id").(string)
+		secret, err := config.sdk.LockboxSecret().Secret().Get(ctx, &lockbox.GetSecretRequest{
+			SecretId: secretID,
+		})
+		if err != nil {
+			return diag.Errorf("could not get secret %v: %s", secretID, err)
+		}
+		if secret.GetPasswordPayloadSpecification() == nil {
+			return diag.Errorf("entries must be set to add a version to secret %v, which has no password_payload_specification", secretID)
+		}
+
+		// Lockbox generates the payload by the password_payload_specification of the secret
+		return addLockboxSecretVersion(ctx, d, meta, &lockbox.AddVersionRequest{
+			SecretId:    d.Get("secret_id").(string),
+			Description: d.Get("description").(string),
+		})
+	}
+
 	getPayloadReq := &lockbox.GetPayloadRequest{
 		SecretId: d.Get("secret_id").(string),
 		// It's not relevant what version to use as base, since addEntryChangesForRemovedKeys will just leave the versionPayloadEntries.
@@ -135,6 +170,12 @@ func resourceYandexLockboxSecretVersionCreate(ctx context.Context, d *schema.Res
 		PayloadEntries: addEntryChangesForRemovedKeys(payload.Entries, versionPayloadEntries),
 	}
 
+	return addLockboxSecretVersion(ctx, d, meta, req)
+}
+
+func addLockboxSecretVersion(ctx context.Context, d *schema.ResourceData, meta interface{}, req *lockbox.AddVersionRequest) diag.Diagnostics {
+	config := meta.(*Config)
+
 	log.Printf("[INFO] adding Lockbox version for secret with ID: %s, base version ID: %s", req.SecretId, req.BaseVersionId)
 
 	op, err := config.sdk.WrapOperation(config.sdk.LockboxSecret().Secret().AddVersion(ctx, req))
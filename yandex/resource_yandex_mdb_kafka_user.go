@@ -47,9 +47,22 @@ func resourceYandexMDBKafkaUser() *schema.Resource {
 				ForceNew: true,
 			},
 			"password": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				WriteOnly:    true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
 			},
 			"permission": {
 				Type:     schema.TypeSet,
@@ -93,9 +106,13 @@ func buildKafkaUserPermissions(d *schema.ResourceData) ([]*kafka.Permission, boo
 }
 
 func buildKafkaUserSpec(d *schema.ResourceData) (*kafka.UserSpec, error) {
+	password, err := getMDBUserPassword(d)
+	if err != nil {
+		return nil, err
+	}
 	userSpec := &kafka.UserSpec{
 		Name:     d.Get("name").(string),
-		Password: d.Get("password").(string),
+		Password: password,
 	}
 	permissions, ok, err := buildKafkaUserPermissions(d)
 	if err != nil {
@@ -137,10 +154,14 @@ func resourceYandexMDBKafkaUserUpdate(d *schema.ResourceData, meta interface{})
 	ctx, cancel := config.ContextWithTimeout(d.Timeout(schema.TimeoutUpdate))
 	defer cancel()
 
+	password, err := getMDBUserPassword(d)
+	if err != nil {
+		return err
+	}
 	request := &kafka.UpdateUserRequest{
 		ClusterId: d.Get("cluster_id").(string),
 		UserName:  d.Get("name").(string),
-		Password:  d.Get("password").(string),
+		Password:  password,
 	}
 
 	permissions, ok, err := buildKafkaUserPermissions(d)
@@ -157,6 +178,9 @@ func resourceYandexMDBKafkaUserUpdate(d *schema.ResourceData, meta interface{})
 			updatePaths = append(updatePaths, maskField)
 		}
 	}
+	if mdbUserPasswordChanged(d) {
+		updatePaths = append(updatePaths, "password")
+	}
 	if len(updatePaths) == 0 {
 		return nil
 	}
@@ -169,7 +193,6 @@ func resourceYandexMDBKafkaUserUpdate(d *schema.ResourceData, meta interface{})
 }
 
 var mdbKafkaUserUpdateFieldsMap = map[string]string{
-	"password":   "password",
 	"permission": "permissions",
 }
 
@@ -48,9 +48,22 @@ func resourceYandexMDBMySQLUser() *schema.Resource {
 				Required: true,
 			},
 			"password": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				WriteOnly:    true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
 			},
 			"permission": {
 				Type:     schema.TypeSet,
@@ -173,9 +186,11 @@ func expandMySQLUserSpec(d *schema.ResourceData) (*mysql.UserSpec, error) {
 		user.Name = v.(string)
 	}
 
-	if v, ok := d.GetOk("password"); ok {
-		user.Password = v.(string)
+	password, err := getMDBUserPassword(d)
+	if err != nil {
+		return nil, err
 	}
+	user.Password = password
 
 	if v, ok := d.GetOk("permission"); ok {
 		permissions, err := expandMysqlUserPermissions(v.(*schema.Set))
@@ -258,6 +273,12 @@ func resourceYandexMDBMySQLUserUpdate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	updatePaths := []string{"authentication_plugin", "permissions", "connection_limits", "global_permissions"}
+	// Write-only password is sent only when its version is bumped.
+	if _, ok := d.GetOk("password"); ok || mdbUserPasswordChanged(d) {
+		updatePaths = append(updatePaths, "password")
+	}
+
 	clusterID := d.Get("cluster_id").(string)
 	request := &mysql.UpdateUserRequest{
 		ClusterId:            clusterID,
@@ -267,7 +288,7 @@ func resourceYandexMDBMySQLUserUpdate(d *schema.ResourceData, meta interface{})
 		AuthenticationPlugin: user.AuthenticationPlugin,
 		ConnectionLimits:     user.ConnectionLimits,
 		GlobalPermissions:    user.GlobalPermissions,
-		UpdateMask:           &field_mask.FieldMask{Paths: []string{"authentication_plugin", "password", "permissions", "connection_limits", "global_permissions"}},
+		UpdateMask:           &field_mask.FieldMask{Paths: updatePaths},
 	}
 	op, err := retryConflictingOperation(ctx, config, func() (*operation.Operation, error) {
 		log.Printf("[DEBUG] Sending MySQL user update request: %+v", request)
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/stretchr/testify/assert"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/postgresql/v1"
 )
@@ -110,6 +111,37 @@ resource "yandex_mdb_postgresql_user" "alice" {
 	})
 }
 
+// Test that a PostgreSQL User can be created with write-only password and the password can be rotated
+func TestAccMDBPostgreSQLUser_writeOnlyPassword(t *testing.T) {
+	t.Parallel()
+	clusterName := acctest.RandomWithPrefix("tf-postgresql-user-wo")
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMDBPostgreSQLUserConfigWriteOnlyPassword(clusterName, "mysecureP@ssw0rd", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(pgUserResourceNameAlice, "name", "alice"),
+					resource.TestCheckResourceAttr(pgUserResourceNameAlice, "password_wo_version", "1"),
+					resource.TestCheckResourceAttr(pgUserResourceNameAlice, "password", ""),
+					resource.TestCheckNoResourceAttr(pgUserResourceNameAlice, "password_wo"),
+				),
+			},
+			{
+				Config: testAccMDBPostgreSQLUserConfigWriteOnlyPassword(clusterName, "myRotatedP@ssw0rd", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(pgUserResourceNameAlice, "password_wo_version", "2"),
+					resource.TestCheckNoResourceAttr(pgUserResourceNameAlice, "password_wo"),
+				),
+			},
+		},
+	})
+}
+
 func mdbPostgreSQLUserImportStep(name string) resource.TestStep {
 	return resource.TestStep{
 		ResourceName:      name,
@@ -291,3 +323,14 @@ resource "yandex_mdb_postgresql_user" "charlie" {
 	conn_limit = 0
 }`
 }
+
+// Create user with write-only password
+func testAccMDBPostgreSQLUserConfigWriteOnlyPassword(name, password string, version int) string {
+	return testAccMDBPostgreSQLUserConfigStep0(name) + fmt.Sprintf(`
+resource "yandex_mdb_postgresql_user" "alice" {
+	cluster_id          = yandex_mdb_postgresql_cluster.foo.id
+	name                = "alice"
+	password_wo         = "%s"
+	password_wo_version = %d
+}`, password, version)
+}
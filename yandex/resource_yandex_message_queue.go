@@ -3,7 +3,6 @@ package yandex
 import (
 	"fmt"
 	"log"
-	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -18,6 +17,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/logging"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ymq"
 )
 
 const defaultYMQRegion = "ru-central1"
@@ -460,17 +462,12 @@ func resourceYandexMessageQueueReadImpl(d *schema.ResourceData, meta interface{}
 }
 
 func extractNameFromQueueUrl(queue string) (string, error) {
-	// Example: https://message-queue.api.cloud.yandex.net/b1g8ad42m6he1ooql78r/dj6000000000qq9v07ol/yet-another-queue
-	u, err := url.Parse(queue)
+	queueURL, err := ymq.ParseQueueURL(queue)
 	if err != nil {
 		return "", err
 	}
-	segments := strings.Split(u.Path, "/")
-	if len(segments) != 4 {
-		return "", fmt.Errorf("Message queue url was not parsed correctly")
-	}
 
-	return segments[3], nil
+	return queueURL.Name, nil
 }
 
 func validateQueueName(v interface{}, k string) (ws []string, errors []error) {
@@ -550,7 +547,12 @@ func getKeysForYMQClient(d *schema.ResourceData, meta interface{}) (accessKey, s
 	} else { // Keys are in provider
 		providerConfig := meta.(*Config)
 		if providerConfig.YMQAccessKey == "" || providerConfig.YMQSecretKey == "" {
-			err = fmt.Errorf("Message queue access and secret keys should not be empty. They must be specified either in message queue resource or in provider")
+			accessKey, secretKey, err = providerConfig.temporaryStaticAccessKeys.get(providerConfig.Context(), temporaryYMQKeyRoleID)
+			if err != nil {
+				err = fmt.Errorf("Message queue access and secret keys are not specified either in message queue resource or in provider: %w", err)
+				return
+			}
+			log.Printf("[DEBUG] Use temporary access and secret keys")
 			return
 		}
 		accessKey, secretKey = providerConfig.YMQAccessKey, providerConfig.YMQSecretKey
@@ -561,11 +563,15 @@ func getKeysForYMQClient(d *schema.ResourceData, meta interface{}) (accessKey, s
 }
 
 func newYMQClientConfigFromKeys(accessKey, secretKey string, providerConfig *Config) *aws.Config {
-	return &aws.Config{
+	config := &aws.Config{
 		Credentials: credentials.NewStaticCredentials(accessKey, secretKey, ""),
 		Endpoint:    aws.String(providerConfig.YMQEndpoint),
 		Region:      aws.String(providerConfig.Region),
 	}
+	if httpClient := providerConfig.httpClient(ymqServiceName, logging.AuditProtocolSQS); httpClient != nil {
+		config.HTTPClient = httpClient
+	}
+	return config
 }
 
 func newYMQClientConfig(d *schema.ResourceData, meta interface{}) (config *aws.Config, err error) {
@@ -269,7 +269,7 @@ func testAccCheckMessageQueueDestroy(s *terraform.State) error {
 func testAccCheckMessageQueueDestroyWithProvider(s *terraform.State, provider *schema.Provider) error {
 	// Create temporary credentials, because credentials
 	// that existed during creation of the queue were deleted in terraform destroy procedure.
-	accessKey, secretKey, cleanup, err := createTemporaryStaticAccessKey("editor", testAccProvider.Meta().(*Config))
+	accessKey, secretKey, cleanup, err := createTemporaryStaticAccessKey(context.Background(), "editor", testAccProvider.Meta().(*Config))
 	if err != nil {
 		return err
 	}
@@ -513,7 +513,7 @@ func testAccMessageQueueSetTmpKeysForProvider() (cleanupFunc func(), err error)
 	}
 
 	// Create additional key pair for testing of import.
-	accessKey, secretKey, cleanup, err := createTemporaryStaticAccessKey("editor", &config)
+	accessKey, secretKey, cleanup, err := createTemporaryStaticAccessKey(context.Background(), "editor", &config)
 	if err != nil {
 		err = fmt.Errorf("Failed to create credentials: %s", err)
 		return
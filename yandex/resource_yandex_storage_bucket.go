@@ -23,6 +23,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/corehandlers"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -544,6 +546,84 @@ func resourceYandexStorageBucket() *schema.Resource {
 				},
 			},
 
+			"replication_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"rules": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: validation.StringLenBetween(0, 255),
+									},
+									"priority": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Computed: true,
+									},
+									"status": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(s3.ReplicationRuleStatus_Values(), false),
+									},
+									"filter": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"prefix": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+												"tags": tagsSchema(),
+											},
+										},
+									},
+									"destination": {
+										Type:     schema.TypeList,
+										Required: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"bucket": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"storage_class": {
+													Type:         schema.TypeString,
+													Optional:     true,
+													Computed:     true,
+													ValidateFunc: validation.StringInSlice(storageClassSet, false),
+												},
+											},
+										},
+									},
+									"delete_marker_replication_status": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: validation.StringInSlice(s3.DeleteMarkerReplicationStatus_Values(), false),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
 			// These fields use extended API and requires IAM token
 			// to be set in order to operate.
 			"default_storage_class": {
@@ -829,6 +909,8 @@ func resourceYandexStorageBucketUpdateBasic(ctx context.Context, d *schema.Resou
 		{"logging", resourceYandexStorageBucketLoggingUpdate},
 		{"lifecycle_rule", resourceYandexStorageBucketLifecycleUpdate},
 		{"server_side_encryption_configuration", resourceYandexStorageBucketServerSideEncryptionConfigurationUpdate},
+		// Replication requires versioning, so it goes after it
+		{"replication_configuration", resourceYandexStorageBucketReplicationConfigurationUpdate},
 		{"object_lock_configuration", resourceYandexStorageBucketObjectLockConfigurationUpdate},
 		{"tags", resourceYandexStorageBucketTagsUpdate},
 	}
@@ -1051,7 +1133,154 @@ func resourceYandexStorageBucketReadBasic(ctx context.Context, d *schema.Resourc
 	}
 	d.Set("bucket_domain_name", domainName)
 
-	// Read the policy
+	if err := readStorageBucketSharedConfigurations(ctx, s3Client, d, config.StorageBucketStandaloneConfigurations); err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+
+	// Add website_endpoint as an attribute
+	websiteEndpoint, err := websiteEndpoint(s3Client, d)
+	if err != nil {
+		return err
+	}
+	if websiteEndpoint != nil {
+		if err := d.Set("website_endpoint", websiteEndpoint.Endpoint); err != nil {
+			return fmt.Errorf("error setting website_endpoint: %s", err)
+		}
+		if err := d.Set("website_domain", websiteEndpoint.Domain); err != nil {
+			return fmt.Errorf("error setting website_domain: %s", err)
+		}
+	}
+
+	if d.Get("acl").(string) == "" {
+		apResponse, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
+			return s3Client.GetBucketAclWithContext(ctx, &s3.GetBucketAclInput{
+				Bucket: bucketAWS,
+			})
+		})
+
+		if !d.IsNewResource() && isAWSErr(err, s3.ErrCodeNoSuchBucket, "") {
+			log.Printf("[WARN] requested bucket not found, deleting")
+			d.SetId("")
+			return nil
+		}
+
+		if err != nil {
+			// Ignore access denied error, when reading ACL for bucket.
+			if awsErr, ok := err.(awserr.Error); ok && (awsErr.Code() == "AccessDenied" || awsErr.Code() == "Forbidden") {
+				log.Printf("[WARN] Got an error while trying to read Storage Bucket (%s) ACL: %s", d.Id(), err)
+
+				if err := d.Set("grant", nil); err != nil {
+					return fmt.Errorf("error resetting Storage Bucket `grant` %s", err)
+				}
+
+				return nil
+			}
+
+			return fmt.Errorf("error getting Storage Bucket (%s) ACL: %s", d.Id(), err)
+		} else {
+			log.Printf("[DEBUG] getting storage: %s, read ACL grants policy: %+v", d.Id(), apResponse)
+			grants := flattenGrants(apResponse.(*s3.GetBucketAclOutput))
+			if err := d.Set("grant", schema.NewSet(grantHash, grants)); err != nil {
+				return fmt.Errorf("error setting Storage Bucket `grant` %s", err)
+			}
+		}
+	} else {
+		if err := d.Set("grant", nil); err != nil {
+			return fmt.Errorf("error resetting Storage Bucket `grant` %s", err)
+		}
+	}
+
+	if err := resourceYandexStorageBucketVersioningRead(ctx, s3Client, d); err != nil {
+		return err
+	}
+
+	// Read the Object Lock Configuration
+	objectLockConfigResponse, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
+		return s3Client.GetObjectLockConfigurationWithContext(ctx, &s3.GetObjectLockConfigurationInput{
+			Bucket: bucketAWS,
+		})
+	})
+	if err != nil &&
+		(!isAWSErr(err, "ObjectLockConfigurationNotFoundError", "") && !isAWSErr(err, "AccessDenied", "")) {
+		log.Printf("[WARN] Got an error while trying to read Storage Bucket (%s) ObjectLockConfiguration: %s", d.Id(), err)
+		return err
+	} else {
+		log.Printf("[DEBUG] Got an error while trying to read Storage Bucket (%s) ObjectLockConfigurationt: %s", d.Id(), err)
+	}
+
+	var olcl []map[string]interface{}
+	objectLockConfig, ok := objectLockConfigResponse.(*s3.GetObjectLockConfigurationOutput)
+	if err == nil && ok && objectLockConfig.ObjectLockConfiguration != nil {
+		log.Printf("[DEBUG] Storage get bucket object lock config output: %#v", objectLockConfig)
+		olcl = make([]map[string]interface{}, 0, 1)
+		olc := make(map[string]interface{})
+
+		enabled := objectLockConfig.ObjectLockConfiguration.ObjectLockEnabled
+		rule := objectLockConfig.ObjectLockConfiguration.Rule
+
+		if aws.StringValue(enabled) != "" {
+			olc["object_lock_enabled"] = aws.StringValue(enabled)
+		}
+
+		if rule != nil {
+			rt := make(map[string]interface{}, 2)
+			defaultRetention := rule.DefaultRetention
+
+			rt["mode"] = aws.StringValue(defaultRetention.Mode)
+			if defaultRetention.Days != nil {
+				rt["days"] = aws.Int64Value(defaultRetention.Days)
+			}
+			if defaultRetention.Years != nil {
+				rt["years"] = aws.Int64Value(defaultRetention.Years)
+			}
+
+			dr := make(map[string]interface{})
+			dr["default_retention"] = []interface{}{rt}
+			olc["rule"] = []interface{}{dr}
+		}
+
+		olcl = append(olcl, olc)
+	}
+	if err := d.Set("object_lock_configuration", olcl); err != nil {
+		return fmt.Errorf("error setting object lock configuration: %s", err)
+	}
+
+	// Read the bucket replication configuration
+	replicationConfiguration, err := readStorageBucketReplicationConfiguration(ctx, s3Client, d.Id())
+	if err != nil {
+		return err
+	}
+	if replicationConfiguration != nil {
+		if err := d.Set("replication_configuration", replicationConfiguration); err != nil {
+			return fmt.Errorf("error setting replication_configuration: %s", err)
+		}
+	}
+
+	getBucketTagging, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
+		return s3Client.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{
+			Bucket: bucketAWS,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error getting S3 Bucket tags: %w", err)
+	}
+
+	tags := getBucketTagging.(*s3.GetBucketTaggingOutput)
+	tagsNormalized := storageBucketTaggingNormalize(tags.TagSet)
+	err = d.Set("tags", tagsNormalized)
+	if err != nil {
+		return fmt.Errorf("error setting S3 Bucket tags: %w", err)
+	}
+
+	return nil
+}
+
+func resourceYandexStorageBucketPolicyRead(ctx context.Context, s3Client *s3.S3, d *schema.ResourceData) error {
+	bucketAWS := aws.String(d.Id())
+
 	pol, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
 		return s3Client.GetBucketPolicyWithContext(ctx, &s3.GetBucketPolicyInput{
 			Bucket: bucketAWS,
@@ -1083,6 +1312,12 @@ func resourceYandexStorageBucketReadBasic(ctx context.Context, d *schema.Resourc
 		return fmt.Errorf("error getting current policy: %s", err)
 	}
 
+	return nil
+}
+
+func resourceYandexStorageBucketCORSRead(ctx context.Context, s3Client *s3.S3, d *schema.ResourceData) error {
+	bucketAWS := aws.String(d.Id())
+
 	corsResponse, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
 		return s3Client.GetBucketCorsWithContext(ctx, &s3.GetBucketCorsInput{
 			Bucket: bucketAWS,
@@ -1119,7 +1354,12 @@ func resourceYandexStorageBucketReadBasic(ctx context.Context, d *schema.Resourc
 		return fmt.Errorf("error setting cors_rule: %s", err)
 	}
 
-	// Read the website configuration
+	return nil
+}
+
+func resourceYandexStorageBucketWebsiteRead(ctx context.Context, s3Client *s3.S3, d *schema.ResourceData) error {
+	bucketAWS := aws.String(d.Id())
+
 	wsResponse, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
 		return s3Client.GetBucketWebsiteWithContext(ctx, &s3.GetBucketWebsiteInput{
 			Bucket: bucketAWS,
@@ -1190,60 +1430,11 @@ func resourceYandexStorageBucketReadBasic(ctx context.Context, d *schema.Resourc
 		return fmt.Errorf("error setting website: %s", err)
 	}
 
-	// Add website_endpoint as an attribute
-	websiteEndpoint, err := websiteEndpoint(s3Client, d)
-	if err != nil {
-		return err
-	}
-	if websiteEndpoint != nil {
-		if err := d.Set("website_endpoint", websiteEndpoint.Endpoint); err != nil {
-			return fmt.Errorf("error setting website_endpoint: %s", err)
-		}
-		if err := d.Set("website_domain", websiteEndpoint.Domain); err != nil {
-			return fmt.Errorf("error setting website_domain: %s", err)
-		}
-	}
-
-	if d.Get("acl").(string) == "" {
-		apResponse, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
-			return s3Client.GetBucketAclWithContext(ctx, &s3.GetBucketAclInput{
-				Bucket: bucketAWS,
-			})
-		})
-
-		if !d.IsNewResource() && isAWSErr(err, s3.ErrCodeNoSuchBucket, "") {
-			log.Printf("[WARN] requested bucket not found, deleting")
-			d.SetId("")
-			return nil
-		}
-
-		if err != nil {
-			// Ignore access denied error, when reading ACL for bucket.
-			if awsErr, ok := err.(awserr.Error); ok && (awsErr.Code() == "AccessDenied" || awsErr.Code() == "Forbidden") {
-				log.Printf("[WARN] Got an error while trying to read Storage Bucket (%s) ACL: %s", d.Id(), err)
-
-				if err := d.Set("grant", nil); err != nil {
-					return fmt.Errorf("error resetting Storage Bucket `grant` %s", err)
-				}
-
-				return nil
-			}
-
-			return fmt.Errorf("error getting Storage Bucket (%s) ACL: %s", d.Id(), err)
-		} else {
-			log.Printf("[DEBUG] getting storage: %s, read ACL grants policy: %+v", d.Id(), apResponse)
-			grants := flattenGrants(apResponse.(*s3.GetBucketAclOutput))
-			if err := d.Set("grant", schema.NewSet(grantHash, grants)); err != nil {
-				return fmt.Errorf("error setting Storage Bucket `grant` %s", err)
-			}
-		}
-	} else {
-		if err := d.Set("grant", nil); err != nil {
-			return fmt.Errorf("error resetting Storage Bucket `grant` %s", err)
-		}
-	}
+	return nil
+}
 
-	// Read the versioning configuration
+func resourceYandexStorageBucketVersioningRead(ctx context.Context, s3Client *s3.S3, d *schema.ResourceData) error {
+	bucketAWS := aws.String(d.Id())
 
 	versioningResponse, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
 		return s3Client.GetBucketVersioningWithContext(ctx, &s3.GetBucketVersioningInput{
@@ -1269,58 +1460,12 @@ func resourceYandexStorageBucketReadBasic(ctx context.Context, d *schema.Resourc
 		return fmt.Errorf("error setting versioning: %s", err)
 	}
 
-	// Read the Object Lock Configuration
-	objectLockConfigResponse, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
-		return s3Client.GetObjectLockConfigurationWithContext(ctx, &s3.GetObjectLockConfigurationInput{
-			Bucket: bucketAWS,
-		})
-	})
-	if err != nil &&
-		(!isAWSErr(err, "ObjectLockConfigurationNotFoundError", "") && !isAWSErr(err, "AccessDenied", "")) {
-		log.Printf("[WARN] Got an error while trying to read Storage Bucket (%s) ObjectLockConfiguration: %s", d.Id(), err)
-		return err
-	} else {
-		log.Printf("[DEBUG] Got an error while trying to read Storage Bucket (%s) ObjectLockConfigurationt: %s", d.Id(), err)
-	}
-
-	var olcl []map[string]interface{}
-	objectLockConfig, ok := objectLockConfigResponse.(*s3.GetObjectLockConfigurationOutput)
-	if err == nil && ok && objectLockConfig.ObjectLockConfiguration != nil {
-		log.Printf("[DEBUG] Storage get bucket object lock config output: %#v", objectLockConfig)
-		olcl = make([]map[string]interface{}, 0, 1)
-		olc := make(map[string]interface{})
-
-		enabled := objectLockConfig.ObjectLockConfiguration.ObjectLockEnabled
-		rule := objectLockConfig.ObjectLockConfiguration.Rule
-
-		if aws.StringValue(enabled) != "" {
-			olc["object_lock_enabled"] = aws.StringValue(enabled)
-		}
-
-		if rule != nil {
-			rt := make(map[string]interface{}, 2)
-			defaultRetention := rule.DefaultRetention
-
-			rt["mode"] = aws.StringValue(defaultRetention.Mode)
-			if defaultRetention.Days != nil {
-				rt["days"] = aws.Int64Value(defaultRetention.Days)
-			}
-			if defaultRetention.Years != nil {
-				rt["years"] = aws.Int64Value(defaultRetention.Years)
-			}
-
-			dr := make(map[string]interface{})
-			dr["default_retention"] = []interface{}{rt}
-			olc["rule"] = []interface{}{dr}
-		}
+	return nil
+}
 
-		olcl = append(olcl, olc)
-	}
-	if err := d.Set("object_lock_configuration", olcl); err != nil {
-		return fmt.Errorf("error setting object lock configuration: %s", err)
-	}
+func resourceYandexStorageBucketLoggingRead(ctx context.Context, s3Client *s3.S3, d *schema.ResourceData) error {
+	bucketAWS := aws.String(d.Id())
 
-	// Read the logging configuration
 	loggingResponse, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
 		return s3Client.GetBucketLoggingWithContext(ctx, &s3.GetBucketLoggingInput{
 			Bucket: bucketAWS,
@@ -1347,7 +1492,12 @@ func resourceYandexStorageBucketReadBasic(ctx context.Context, d *schema.Resourc
 		return fmt.Errorf("error setting logging: %s", err)
 	}
 
-	// Read the lifecycle configuration
+	return nil
+}
+
+func resourceYandexStorageBucketLifecycleRead(ctx context.Context, s3Client *s3.S3, d *schema.ResourceData) error {
+	bucketAWS := aws.String(d.Id())
+
 	lifecycleResponse, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
 		return s3Client.GetBucketLifecycleConfigurationWithContext(ctx, &s3.GetBucketLifecycleConfigurationInput{
 			Bucket: bucketAWS,
@@ -1494,7 +1644,11 @@ func resourceYandexStorageBucketReadBasic(ctx context.Context, d *schema.Resourc
 		return fmt.Errorf("error setting lifecycle_rule: %s", err)
 	}
 
-	// Read the bucket server side encryption configuration
+	return nil
+}
+
+func resourceYandexStorageBucketServerSideEncryptionConfigurationRead(ctx context.Context, s3Client *s3.S3, d *schema.ResourceData) error {
+	bucketAWS := aws.String(d.Id())
 
 	encryptionResponse, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
 		return s3Client.GetBucketEncryptionWithContext(ctx, &s3.GetBucketEncryptionInput{
@@ -1513,22 +1667,24 @@ func resourceYandexStorageBucketReadBasic(ctx context.Context, d *schema.Resourc
 		return fmt.Errorf("error setting server_side_encryption_configuration: %s", err)
 	}
 
-	getBucketTagging, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
-		return s3Client.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{
-			Bucket: bucketAWS,
-		})
-	})
-	if err != nil {
-		return fmt.Errorf("error getting S3 Bucket tags: %w", err)
-	}
+	return nil
+}
 
-	tags := getBucketTagging.(*s3.GetBucketTaggingOutput)
-	tagsNormalized := storageBucketTaggingNormalize(tags.TagSet)
-	err = d.Set("tags", tagsNormalized)
-	if err != nil {
-		return fmt.Errorf("error setting S3 Bucket tags: %w", err)
+// readStorageBucketSharedConfigurations reads sub-configurations, which may be managed by standalone resources.
+// With standalone set, they are refreshed only if they are set in the bucket state, so that the bucket does not
+// fight over them with those resources.
+func readStorageBucketSharedConfigurations(ctx context.Context, s3Client *s3.S3, d *schema.ResourceData, standalone bool) error {
+	for _, c := range storageBucketSharedConfigurations {
+		if _, ok := d.GetOk(c.name); !ok && standalone {
+			continue
+		}
+		if err := c.read(ctx, s3Client, d); err != nil {
+			return err
+		}
+		if d.Id() == "" {
+			return nil
+		}
 	}
-
 	return nil
 }
 
@@ -2216,6 +2372,12 @@ func retryFlakyS3Responses(ctx context.Context, f func() (interface{}, error)) (
 	return retryOnAwsCodes(ctx, []string{"NoSuchBucket", "AccessDenied", "Forbidden"}, f)
 }
 
+// Intervals between checks of waitConditionStable, which are shortened in unit tests.
+var (
+	waitConditionStableSubcheckInterval = time.Second
+	waitConditionStableCheckInterval    = 5 * time.Second
+)
+
 func waitConditionStable(check func() (bool, error)) error {
 	for checks := 0; checks < 12; checks++ {
 		allOk := true
@@ -2226,13 +2388,13 @@ func waitConditionStable(check func() (bool, error)) error {
 			}
 			allOk = allOk && ok
 			if ok {
-				time.Sleep(time.Second)
+				time.Sleep(waitConditionStableSubcheckInterval)
 			}
 		}
 		if allOk {
 			return nil
 		}
-		time.Sleep(5 * time.Second)
+		time.Sleep(waitConditionStableCheckInterval)
 	}
 
 	return fmt.Errorf("timeout exceeded")
@@ -2316,6 +2478,48 @@ func waitCorsPut(ctx context.Context, s3Client *s3.S3, bucket string, configurat
 	return nil
 }
 
+func waitReplicationPut(ctx context.Context, s3Client *s3.S3, bucket string, configuration *s3.ReplicationConfiguration) error {
+	input := &s3.GetBucketReplicationInput{Bucket: aws.String(bucket)}
+
+	check := func() (bool, error) {
+		output, err := s3Client.GetBucketReplicationWithContext(ctx, input)
+		if err != nil && !isAWSErr(err, "ReplicationConfigurationNotFoundError", "") {
+			return false, err
+		}
+		if output.ReplicationConfiguration == nil {
+			return false, nil
+		}
+		return storageBucketReplicationConfigurationEqual(configuration, output.ReplicationConfiguration), nil
+	}
+
+	err := waitConditionStable(check)
+	if err != nil {
+		return fmt.Errorf("error assuring bucket %q replication configuration updated: %s", bucket, err)
+	}
+	return nil
+}
+
+func waitReplicationDeleted(ctx context.Context, s3Client *s3.S3, bucket string) error {
+	input := &s3.GetBucketReplicationInput{Bucket: aws.String(bucket)}
+
+	check := func() (bool, error) {
+		_, err := s3Client.GetBucketReplicationWithContext(ctx, input)
+		if isAWSErr(err, "ReplicationConfigurationNotFoundError", "") {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	err := waitConditionStable(check)
+	if err != nil {
+		return fmt.Errorf("error assuring bucket %q replication configuration deleted: %s", bucket, err)
+	}
+	return nil
+}
+
 func waitCorsDeleted(ctx context.Context, s3Client *s3.S3, bucket string) error {
 	input := &s3.GetBucketCorsInput{Bucket: aws.String(bucket)}
 
@@ -2799,6 +3003,233 @@ func resourceYandexStorageBucketServerSideEncryptionConfigurationUpdate(ctx cont
 	return nil
 }
 
+func resourceYandexStorageBucketReplicationConfigurationUpdate(ctx context.Context, s3conn *s3.S3, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	replicationConfiguration := d.Get("replication_configuration").([]interface{})
+
+	if len(replicationConfiguration) == 0 {
+		log.Printf("[DEBUG] Storage Bucket: %s, delete replication configuration", bucket)
+
+		_, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
+			return s3conn.DeleteBucketReplicationWithContext(ctx, &s3.DeleteBucketReplicationInput{
+				Bucket: aws.String(bucket),
+			})
+		})
+		if err == nil {
+			err = waitReplicationDeleted(ctx, s3conn, bucket)
+		}
+		if err != nil {
+			return fmt.Errorf("error removing S3 bucket replication configuration: %s", err)
+		}
+		return nil
+	}
+
+	rc := expandStorageBucketReplicationConfiguration(replicationConfiguration[0].(map[string]interface{}))
+	i := &s3.PutBucketReplicationInput{
+		Bucket:                   aws.String(bucket),
+		ReplicationConfiguration: rc,
+	}
+	log.Printf("[DEBUG] S3 put bucket replication configuration: %#v", i)
+
+	var opts []request.Option
+	if rc.Role == nil {
+		opts = append(opts, withoutStorageBucketReplicationRole)
+	}
+	_, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
+		return s3conn.PutBucketReplicationWithContext(ctx, i, opts...)
+	})
+	if err == nil {
+		err = waitReplicationPut(ctx, s3conn, bucket, rc)
+	}
+	if err != nil {
+		return fmt.Errorf("error putting S3 bucket replication configuration: %s", err)
+	}
+
+	return nil
+}
+
+// withoutStorageBucketReplicationRole lets the replication configuration be put without a role. Object Storage
+// doesn't require it, while the SDK validates the role as a required field.
+func withoutStorageBucketReplicationRole(r *request.Request) {
+	r.Handlers.Validate.Remove(corehandlers.ValidateParametersHandler)
+}
+
+// readStorageBucketReplicationConfiguration returns nil, if the configuration can't be read for lack of access.
+func readStorageBucketReplicationConfiguration(ctx context.Context, s3conn *s3.S3, bucket string) ([]map[string]interface{}, error) {
+	replicationResponse, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
+		return s3conn.GetBucketReplicationWithContext(ctx, &s3.GetBucketReplicationInput{
+			Bucket: aws.String(bucket),
+		})
+	})
+	// Ignore access denied error, when reading replication configuration for bucket, so that it is kept in state.
+	if isAWSErr(err, "AccessDenied", "") || isAWSErr(err, "Forbidden", "") {
+		log.Printf("[WARN] Got an error while trying to read Storage Bucket (%s) replication configuration: %s", bucket, err)
+		return nil, nil
+	}
+	if err != nil && !isAWSErr(err, "ReplicationConfigurationNotFoundError", "") && !isAWSErr(err, "NotImplemented", "") {
+		return nil, fmt.Errorf("error getting S3 Bucket replication configuration: %w", err)
+	}
+
+	if replication, ok := replicationResponse.(*s3.GetBucketReplicationOutput); ok && replication.ReplicationConfiguration != nil {
+		log.Printf("[DEBUG] Storage get bucket replication output: %#v", replication)
+		return flattenStorageBucketReplicationConfiguration(replication.ReplicationConfiguration), nil
+	}
+	return make([]map[string]interface{}, 0), nil
+}
+
+func expandStorageBucketReplicationConfiguration(c map[string]interface{}) *s3.ReplicationConfiguration {
+	rc := &s3.ReplicationConfiguration{}
+	if role := c["role"].(string); role != "" {
+		rc.Role = aws.String(role)
+	}
+
+	for _, v := range c["rules"].([]interface{}) {
+		rr := v.(map[string]interface{})
+		rule := &s3.ReplicationRule{
+			Status: aws.String(rr["status"].(string)),
+			// Rule without a filter applies to all objects in the bucket
+			Filter: &s3.ReplicationRuleFilter{Prefix: aws.String("")},
+		}
+		if id := rr["id"].(string); id != "" {
+			rule.ID = aws.String(id)
+		}
+		if priority := rr["priority"].(int); priority != 0 {
+			rule.Priority = aws.Int64(int64(priority))
+		}
+
+		if filters := rr["filter"].([]interface{}); len(filters) > 0 && filters[0] != nil {
+			filter := filters[0].(map[string]interface{})
+			prefix := filter["prefix"].(string)
+			tags := storageBucketTaggingFromMap(convertTypesMap(filter["tags"]))
+			switch {
+			case len(tags) == 0:
+				rule.Filter = &s3.ReplicationRuleFilter{Prefix: aws.String(prefix)}
+			case len(tags) == 1 && prefix == "":
+				rule.Filter = &s3.ReplicationRuleFilter{Tag: tags[0]}
+			default:
+				rule.Filter = &s3.ReplicationRuleFilter{
+					And: &s3.ReplicationRuleAndOperator{
+						Prefix: aws.String(prefix),
+						Tags:   tags,
+					},
+				}
+			}
+		}
+
+		destination := rr["destination"].([]interface{})[0].(map[string]interface{})
+		rule.Destination = &s3.Destination{
+			Bucket: aws.String(destination["bucket"].(string)),
+		}
+		if storageClass := destination["storage_class"].(string); storageClass != "" {
+			rule.Destination.StorageClass = aws.String(storageClass)
+		}
+
+		if status := rr["delete_marker_replication_status"].(string); status != "" {
+			rule.DeleteMarkerReplication = &s3.DeleteMarkerReplication{
+				Status: aws.String(status),
+			}
+		}
+
+		rc.Rules = append(rc.Rules, rule)
+	}
+
+	return rc
+}
+
+func flattenStorageBucketReplicationConfiguration(rc *s3.ReplicationConfiguration) []map[string]interface{} {
+	rules := make([]interface{}, 0, len(rc.Rules))
+	for _, v := range rc.Rules {
+		rule := map[string]interface{}{
+			"id":       aws.StringValue(v.ID),
+			"priority": int(aws.Int64Value(v.Priority)),
+			"status":   aws.StringValue(v.Status),
+		}
+
+		if f := v.Filter; f != nil {
+			filter := make(map[string]interface{})
+			switch {
+			case f.And != nil:
+				if prefix := aws.StringValue(f.And.Prefix); prefix != "" {
+					filter["prefix"] = prefix
+				}
+				if tags := storageBucketTaggingNormalize(f.And.Tags); tags != nil {
+					filter["tags"] = tags
+				}
+			case f.Tag != nil:
+				filter["tags"] = storageBucketTaggingNormalize([]*s3.Tag{f.Tag})
+			case aws.StringValue(f.Prefix) != "":
+				filter["prefix"] = aws.StringValue(f.Prefix)
+			}
+			// Empty filter is the same as no filter at all
+			if len(filter) > 0 {
+				rule["filter"] = []interface{}{filter}
+			}
+		}
+
+		if dst := v.Destination; dst != nil {
+			destination := map[string]interface{}{
+				"bucket": aws.StringValue(dst.Bucket),
+			}
+			if dst.StorageClass != nil {
+				destination["storage_class"] = aws.StringValue(dst.StorageClass)
+			}
+			rule["destination"] = []interface{}{destination}
+		}
+
+		if v.DeleteMarkerReplication != nil {
+			rule["delete_marker_replication_status"] = aws.StringValue(v.DeleteMarkerReplication.Status)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return []map[string]interface{}{{
+		"role":  aws.StringValue(rc.Role),
+		"rules": rules,
+	}}
+}
+
+// storageBucketReplicationConfigurationEqual compares configurations, ignoring
+// the rule fields which are filled by the server, when they are not set explicitly.
+func storageBucketReplicationConfigurationEqual(expected, actual *s3.ReplicationConfiguration) bool {
+	e := flattenStorageBucketReplicationConfiguration(expected)[0]
+	a := flattenStorageBucketReplicationConfiguration(actual)[0]
+
+	expectedRules := e["rules"].([]interface{})
+	actualRules := a["rules"].([]interface{})
+	if len(expectedRules) != len(actualRules) {
+		return false
+	}
+	for i := range expectedRules {
+		expectedRule := expectedRules[i].(map[string]interface{})
+		actualRule := actualRules[i].(map[string]interface{})
+		for _, k := range []string{"id", "delete_marker_replication_status"} {
+			if v, ok := expectedRule[k]; !ok || v == "" {
+				delete(expectedRule, k)
+				delete(actualRule, k)
+			}
+		}
+		if expectedRule["priority"] == 0 {
+			delete(expectedRule, "priority")
+			delete(actualRule, "priority")
+		}
+		expectedDestination := storageBucketReplicationRuleDestination(expectedRule)
+		actualDestination := storageBucketReplicationRuleDestination(actualRule)
+		if _, ok := expectedDestination["storage_class"]; !ok && actualDestination != nil {
+			delete(actualDestination, "storage_class")
+		}
+	}
+
+	return reflect.DeepEqual(e, a)
+}
+
+func storageBucketReplicationRuleDestination(rule map[string]interface{}) map[string]interface{} {
+	if destination, ok := rule["destination"].([]interface{}); ok && len(destination) > 0 {
+		return destination[0].(map[string]interface{})
+	}
+	return nil
+}
+
 func flattenGrants(ap *s3.GetBucketAclOutput) []interface{} {
 	//if ACL grants contains bucket owner FULL_CONTROL only - it is default "private" acl
 	if len(ap.Grants) == 1 && aws.StringValue(ap.Grants[0].Grantee.ID) == aws.StringValue(ap.Owner.ID) &&
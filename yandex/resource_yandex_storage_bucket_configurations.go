@@ -0,0 +1,217 @@
+package yandex
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// storageBucketConfiguration is a sub-configuration of the bucket, which may be managed either
+// by yandex_storage_bucket itself, or by a standalone resource, like yandex_storage_bucket_cors_configuration.
+// Both use the same attribute name, so the same read and update handlers work for both of them.
+type storageBucketConfiguration struct {
+	name   string
+	read   func(context.Context, *s3.S3, *schema.ResourceData) error
+	update func(context.Context, *s3.S3, *schema.ResourceData) error
+}
+
+var (
+	storageBucketPolicyConfiguration = storageBucketConfiguration{
+		name:   "policy",
+		read:   resourceYandexStorageBucketPolicyRead,
+		update: resourceYandexStorageBucketPolicyUpdate,
+	}
+	storageBucketCORSConfiguration = storageBucketConfiguration{
+		name:   "cors_rule",
+		read:   resourceYandexStorageBucketCORSRead,
+		update: resourceYandexStorageBucketCORSUpdate,
+	}
+	storageBucketWebsiteConfiguration = storageBucketConfiguration{
+		name:   "website",
+		read:   resourceYandexStorageBucketWebsiteRead,
+		update: resourceYandexStorageBucketWebsiteUpdate,
+	}
+	storageBucketVersioningConfiguration = storageBucketConfiguration{
+		name:   "versioning",
+		read:   resourceYandexStorageBucketVersioningRead,
+		update: resourceYandexStorageBucketVersioningUpdate,
+	}
+	storageBucketLoggingConfiguration = storageBucketConfiguration{
+		name:   "logging",
+		read:   resourceYandexStorageBucketLoggingRead,
+		update: resourceYandexStorageBucketLoggingUpdate,
+	}
+	storageBucketLifecycleConfiguration = storageBucketConfiguration{
+		name:   "lifecycle_rule",
+		read:   resourceYandexStorageBucketLifecycleRead,
+		update: resourceYandexStorageBucketLifecycleUpdate,
+	}
+	storageBucketServerSideEncryptionConfiguration = storageBucketConfiguration{
+		name:   "server_side_encryption_configuration",
+		read:   resourceYandexStorageBucketServerSideEncryptionConfigurationRead,
+		update: resourceYandexStorageBucketServerSideEncryptionConfigurationUpdate,
+	}
+
+	// storageBucketSharedConfigurations are refreshed by yandex_storage_bucket only if they are set in its state,
+	// when storage_bucket_standalone_configurations is set in the provider.
+	// Versioning is not here, since it is computed in the bucket, so that it does not make a diff anyway.
+	storageBucketSharedConfigurations = []storageBucketConfiguration{
+		storageBucketPolicyConfiguration,
+		storageBucketCORSConfiguration,
+		storageBucketWebsiteConfiguration,
+		storageBucketLoggingConfiguration,
+		storageBucketLifecycleConfiguration,
+		storageBucketServerSideEncryptionConfiguration,
+	}
+)
+
+func resourceYandexStorageBucketPolicy() *schema.Resource {
+	return resourceYandexStorageBucketConfiguration(storageBucketPolicyConfiguration)
+}
+
+func resourceYandexStorageBucketCORSConfiguration() *schema.Resource {
+	return resourceYandexStorageBucketConfiguration(storageBucketCORSConfiguration)
+}
+
+func resourceYandexStorageBucketWebsiteConfiguration() *schema.Resource {
+	return resourceYandexStorageBucketConfiguration(storageBucketWebsiteConfiguration)
+}
+
+func resourceYandexStorageBucketVersioning() *schema.Resource {
+	return resourceYandexStorageBucketConfiguration(storageBucketVersioningConfiguration)
+}
+
+func resourceYandexStorageBucketLogging() *schema.Resource {
+	return resourceYandexStorageBucketConfiguration(storageBucketLoggingConfiguration)
+}
+
+func resourceYandexStorageBucketLifecycleConfiguration() *schema.Resource {
+	return resourceYandexStorageBucketConfiguration(storageBucketLifecycleConfiguration)
+}
+
+func resourceYandexStorageBucketServerSideEncryptionConfiguration() *schema.Resource {
+	return resourceYandexStorageBucketConfiguration(storageBucketServerSideEncryptionConfiguration)
+}
+
+// resourceYandexStorageBucketConfiguration makes a standalone resource for the bucket sub-configuration.
+// Its schema is taken from yandex_storage_bucket, so that both resources are configured the same way.
+func resourceYandexStorageBucketConfiguration(c storageBucketConfiguration) *schema.Resource {
+	configurationSchema := resourceYandexStorageBucket().Schema[c.name]
+	configurationSchema.Optional = false
+	configurationSchema.Computed = false
+	configurationSchema.Required = true
+
+	return &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return resourceYandexStorageBucketConfigurationPut(ctx, d, meta, c)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return resourceYandexStorageBucketConfigurationRead(ctx, d, meta, c)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return resourceYandexStorageBucketConfigurationPut(ctx, d, meta, c)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return resourceYandexStorageBucketConfigurationDelete(ctx, d, meta, c)
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		SchemaVersion: 0,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"access_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"secret_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+
+			c.name: configurationSchema,
+		},
+	}
+}
+
+func resourceYandexStorageBucketConfigurationPut(ctx context.Context, d *schema.ResourceData, meta interface{}, c storageBucketConfiguration) diag.Diagnostics {
+	config := meta.(*Config)
+	s3Client, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	log.Printf("[DEBUG] Storage Bucket: %s, put %s", bucket, c.name)
+
+	if err := c.update(ctx, s3Client, d); err != nil {
+		return diag.Errorf("error handling %s of Storage Bucket (%s): %s", c.name, bucket, err)
+	}
+	d.SetId(bucket)
+
+	return resourceYandexStorageBucketConfigurationRead(ctx, d, meta, c)
+}
+
+func resourceYandexStorageBucketConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}, c storageBucketConfiguration) diag.Diagnostics {
+	config := meta.(*Config)
+	s3Client, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	_, err = retryFlakyS3Responses(ctx, func() (interface{}, error) {
+		return s3Client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+			Bucket: aws.String(d.Id()),
+		})
+	})
+	if err != nil {
+		if handleS3BucketNotFoundError(d, err) {
+			return nil
+		}
+		return diag.Errorf("error reading Storage Bucket (%s): %s", d.Id(), err)
+	}
+
+	if err := d.Set("bucket", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := c.read(ctx, s3Client, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceYandexStorageBucketConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}, c storageBucketConfiguration) diag.Diagnostics {
+	config := meta.(*Config)
+	s3Client, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	log.Printf("[DEBUG] Storage Bucket: %s, delete %s", d.Id(), c.name)
+
+	// Update handlers remove the sub-configuration, when it is empty
+	if err := d.Set(c.name, nil); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := c.update(ctx, s3Client, d); err != nil {
+		return diag.Errorf("error deleting %s of Storage Bucket (%s): %s", c.name, d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package yandex
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestStorageBucketConfigurationResources(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configuration storageBucketConfiguration
+		resource      *schema.Resource
+		notFoundCodes map[string]string
+		value         interface{}
+		empty         interface{}
+	}{
+		{
+			name:          "cors",
+			configuration: storageBucketCORSConfiguration,
+			resource:      resourceYandexStorageBucketCORSConfiguration(),
+			notFoundCodes: map[string]string{"cors": "NoSuchCORSConfiguration"},
+			value: []interface{}{
+				map[string]interface{}{
+					"allowed_headers": []interface{}{"*"},
+					"allowed_methods": []interface{}{"GET", "PUT"},
+					"allowed_origins": []interface{}{"https://example.com"},
+					"expose_headers":  []interface{}{"ETag"},
+					"max_age_seconds": 3000,
+				},
+			},
+			empty: []interface{}{},
+		},
+		{
+			name:          "policy",
+			configuration: storageBucketPolicyConfiguration,
+			resource:      resourceYandexStorageBucketPolicy(),
+			notFoundCodes: map[string]string{"policy": "NoSuchBucketPolicy"},
+			value:         `{"Statement":[{"Action":"s3:GetObject","Effect":"Allow","Principal":"*","Resource":"arn:aws:s3:::test/*"}],"Version":"2012-10-17"}`,
+			empty:         "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s3Client := newTestStorageS3Client(t, tc.notFoundCodes, nil)
+			ctx := context.Background()
+
+			if err := tc.resource.InternalValidate(nil, true); err != nil {
+				t.Fatalf("invalid resource schema: %s", err)
+			}
+			if !tc.resource.Schema[tc.configuration.name].Required {
+				t.Fatalf("%s must be required in the standalone resource", tc.configuration.name)
+			}
+
+			d := schema.TestResourceDataRaw(t, tc.resource.Schema, map[string]interface{}{
+				"bucket":              "test",
+				tc.configuration.name: tc.value,
+			})
+			d.SetId("test")
+			if err := tc.configuration.update(ctx, s3Client, d); err != nil {
+				t.Fatalf("failed to put %s: %s", tc.configuration.name, err)
+			}
+
+			read := schema.TestResourceDataRaw(t, tc.resource.Schema, map[string]interface{}{"bucket": "test"})
+			read.SetId("test")
+			if err := tc.configuration.read(ctx, s3Client, read); err != nil {
+				t.Fatalf("failed to read %s: %s", tc.configuration.name, err)
+			}
+			if actual := read.Get(tc.configuration.name); !reflect.DeepEqual(tc.value, actual) {
+				t.Fatalf("unexpected %s read back:\nexpected: %#v\nactual:   %#v", tc.configuration.name, tc.value, actual)
+			}
+
+			// Empty sub-configuration is removed from the bucket, as it is done on delete
+			if err := d.Set(tc.configuration.name, nil); err != nil {
+				t.Fatalf("failed to reset %s: %s", tc.configuration.name, err)
+			}
+			if err := tc.configuration.update(ctx, s3Client, d); err != nil {
+				t.Fatalf("failed to delete %s: %s", tc.configuration.name, err)
+			}
+			if err := tc.configuration.read(ctx, s3Client, read); err != nil {
+				t.Fatalf("failed to read %s: %s", tc.configuration.name, err)
+			}
+			if actual := read.Get(tc.configuration.name); !reflect.DeepEqual(tc.empty, actual) {
+				t.Fatalf("%s is not deleted: %#v", tc.configuration.name, actual)
+			}
+		})
+	}
+}
+
+func TestReadStorageBucketSharedConfigurations(t *testing.T) {
+	notFoundCodes := map[string]string{
+		"policy":     "NoSuchBucketPolicy",
+		"cors":       "NoSuchCORSConfiguration",
+		"website":    "NoSuchWebsiteConfiguration",
+		"logging":    "NoSuchLoggingConfiguration",
+		"lifecycle":  "NoSuchLifecycleConfiguration",
+		"encryption": "ServerSideEncryptionConfigurationNotFoundError",
+	}
+	s3Client := newTestStorageS3Client(t, notFoundCodes, nil)
+	ctx := context.Background()
+
+	const policy = `{"Statement":[{"Action":"s3:GetObject","Effect":"Allow","Principal":"*","Resource":"arn:aws:s3:::test/*"}],"Version":"2012-10-17"}`
+	d := schema.TestResourceDataRaw(t, resourceYandexStorageBucketPolicy().Schema, map[string]interface{}{
+		"bucket": "test",
+		"policy": policy,
+	})
+	d.SetId("test")
+	if err := storageBucketPolicyConfiguration.update(ctx, s3Client, d); err != nil {
+		t.Fatalf("failed to put policy: %s", err)
+	}
+	// Logging and encryption are never missing in the stand-in, as the logging status is always returned
+	// by Object Storage, and encryption not found error is recognized by its message too.
+	if _, err := s3Client.PutBucketLoggingWithContext(ctx, &s3.PutBucketLoggingInput{
+		Bucket:              aws.String("test"),
+		BucketLoggingStatus: &s3.BucketLoggingStatus{},
+	}); err != nil {
+		t.Fatalf("failed to put logging: %s", err)
+	}
+	if _, err := s3Client.PutBucketEncryptionWithContext(ctx, &s3.PutBucketEncryptionInput{
+		Bucket: aws.String("test"),
+		ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+			Rules: []*s3.ServerSideEncryptionRule{{
+				ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{SSEAlgorithm: aws.String(s3.ServerSideEncryptionAwsKms)},
+			}},
+		},
+	}); err != nil {
+		t.Fatalf("failed to put encryption: %s", err)
+	}
+
+	for _, standalone := range []bool{false, true} {
+		bucket := schema.TestResourceDataRaw(t, resourceYandexStorageBucket().Schema, map[string]interface{}{"bucket": "test"})
+		bucket.SetId("test")
+		if err := readStorageBucketSharedConfigurations(ctx, s3Client, bucket, standalone); err != nil {
+			t.Fatalf("failed to read configurations: %s", err)
+		}
+
+		// Policy managed by the standalone resource is not read into the bucket, otherwise it is detected
+		expected := policy
+		if standalone {
+			expected = ""
+		}
+		if actual := bucket.Get("policy"); actual != expected {
+			t.Errorf("unexpected policy read with standalone %t: %q", standalone, actual)
+		}
+	}
+}
+
+func TestAccStorageBucketCORSConfiguration_basic(t *testing.T) {
+	rInt := acctest.RandInt()
+	resourceName := "yandex_storage_bucket_cors_configuration.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStorageBucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageBucketCORSConfigurationConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStorageBucketExists("yandex_storage_bucket.test"),
+					wrapWithRetries(testAccCheckStorageBucketCors(
+						resourceName,
+						[]*s3.CORSRule{
+							{
+								AllowedMethods: []*string{aws.String("GET")},
+								AllowedOrigins: []*string{aws.String("https://www.example.com")},
+							},
+						},
+					)),
+					resource.TestCheckResourceAttr(resourceName, "cors_rule.#", "1"),
+					// The bucket does not take over CORS configuration managed by the standalone resource
+					resource.TestCheckResourceAttr("yandex_storage_bucket.test", "cors_rule.#", "0"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"access_key", "secret_key"},
+			},
+		},
+	})
+}
+
+func testAccStorageBucketCORSConfigurationConfig(randInt int) string {
+	return newBucketConfigBuilder(randInt).
+		after(`resource "yandex_storage_bucket_cors_configuration" "test" {
+	bucket = yandex_storage_bucket.test.bucket
+
+	access_key = yandex_iam_service_account_static_access_key.sa-key.access_key
+	secret_key = yandex_iam_service_account_static_access_key.sa-key.secret_key
+
+	cors_rule {
+		allowed_methods = ["GET"]
+		allowed_origins = ["https://www.example.com"]
+	}
+}`).
+		asEditor().
+		render()
+}
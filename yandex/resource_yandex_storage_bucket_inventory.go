@@ -0,0 +1,318 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceYandexStorageBucketInventory() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceYandexStorageBucketInventoryPut,
+		ReadContext:   resourceYandexStorageBucketInventoryRead,
+		UpdateContext: resourceYandexStorageBucketInventoryPut,
+		DeleteContext: resourceYandexStorageBucketInventoryDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		SchemaVersion: 0,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 64),
+			},
+
+			"access_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"secret_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"included_object_versions": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(s3.InventoryIncludedObjectVersions_Values(), false),
+			},
+
+			"schedule": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"frequency": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(s3.InventoryFrequency_Values(), false),
+						},
+					},
+				},
+			},
+
+			"destination": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"format": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(s3.InventoryFormat_Values(), false),
+						},
+						"prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"filter": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"optional_fields": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      schema.HashString,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(s3.InventoryOptionalField_Values(), false),
+				},
+			},
+		},
+	}
+}
+
+func resourceYandexStorageBucketInventoryPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	s3Client, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	inventory := expandStorageBucketInventoryConfiguration(d)
+	log.Printf("[DEBUG] Storage Bucket: %s, put inventory configuration: %#v", bucket, inventory)
+
+	_, err = retryFlakyS3Responses(ctx, func() (interface{}, error) {
+		return s3Client.PutBucketInventoryConfigurationWithContext(ctx, &s3.PutBucketInventoryConfigurationInput{
+			Bucket:                 aws.String(bucket),
+			Id:                     inventory.Id,
+			InventoryConfiguration: inventory,
+		})
+	})
+	if isAWSErr(err, "NotImplemented", "") {
+		return diag.Errorf("bucket inventory is not supported by the storage endpoint: %s", err)
+	}
+	if err != nil {
+		return diag.Errorf("error putting Storage Bucket (%s) inventory configuration %q: %s", bucket, aws.StringValue(inventory.Id), err)
+	}
+	d.SetId(bucket + ":" + aws.StringValue(inventory.Id))
+
+	return resourceYandexStorageBucketInventoryRead(ctx, d, meta)
+}
+
+func resourceYandexStorageBucketInventoryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	s3Client, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	bucket, name, err := parseStorageBucketInventoryID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
+		return s3Client.GetBucketInventoryConfigurationWithContext(ctx, &s3.GetBucketInventoryConfigurationInput{
+			Bucket: aws.String(bucket),
+			Id:     aws.String(name),
+		})
+	})
+	if err != nil {
+		if handleS3BucketNotFoundError(d, err) {
+			return nil
+		}
+		if isAWSErr(err, "NoSuchConfiguration", "") {
+			log.Printf("[WARN] Storage Bucket (%s) inventory configuration %q not found, removing from state", bucket, name)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting Storage Bucket (%s) inventory configuration %q: %s", bucket, name, err)
+	}
+	inventory := resp.(*s3.GetBucketInventoryConfigurationOutput).InventoryConfiguration
+	log.Printf("[DEBUG] Storage get bucket inventory configuration output: %#v", inventory)
+
+	d.Set("bucket", bucket)
+	d.Set("name", name)
+	if inventory == nil {
+		return nil
+	}
+
+	d.Set("enabled", aws.BoolValue(inventory.IsEnabled))
+	d.Set("included_object_versions", aws.StringValue(inventory.IncludedObjectVersions))
+	if err := d.Set("schedule", flattenStorageBucketInventorySchedule(inventory.Schedule)); err != nil {
+		return diag.Errorf("error setting schedule: %s", err)
+	}
+	if err := d.Set("destination", flattenStorageBucketInventoryDestination(inventory.Destination)); err != nil {
+		return diag.Errorf("error setting destination: %s", err)
+	}
+	if err := d.Set("filter", flattenStorageBucketInventoryFilter(inventory.Filter)); err != nil {
+		return diag.Errorf("error setting filter: %s", err)
+	}
+	optionalFields := schema.NewSet(schema.HashString, flattenStringList(inventory.OptionalFields))
+	if err := d.Set("optional_fields", optionalFields); err != nil {
+		return diag.Errorf("error setting optional_fields: %s", err)
+	}
+
+	return nil
+}
+
+func resourceYandexStorageBucketInventoryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	s3Client, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	bucket, name, err := parseStorageBucketInventoryID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = retryFlakyS3Responses(ctx, func() (interface{}, error) {
+		return s3Client.DeleteBucketInventoryConfigurationWithContext(ctx, &s3.DeleteBucketInventoryConfigurationInput{
+			Bucket: aws.String(bucket),
+			Id:     aws.String(name),
+		})
+	})
+	if isAWSErr(err, s3.ErrCodeNoSuchBucket, "") || isAWSErr(err, "NoSuchConfiguration", "") {
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("error deleting Storage Bucket (%s) inventory configuration %q: %s", bucket, name, err)
+	}
+
+	return nil
+}
+
+func parseStorageBucketInventoryID(id string) (bucket, name string, err error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid storage bucket inventory id format: %q, expected <bucket>:<name>", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func expandStorageBucketInventoryConfiguration(d *schema.ResourceData) *s3.InventoryConfiguration {
+	inventory := &s3.InventoryConfiguration{
+		Id:                     aws.String(d.Get("name").(string)),
+		IsEnabled:              aws.Bool(d.Get("enabled").(bool)),
+		IncludedObjectVersions: aws.String(d.Get("included_object_versions").(string)),
+		Schedule:               &s3.InventorySchedule{},
+		Destination:            &s3.InventoryDestination{S3BucketDestination: &s3.InventoryS3BucketDestination{}},
+	}
+
+	if v, ok := d.GetOk("schedule.0.frequency"); ok {
+		inventory.Schedule.Frequency = aws.String(v.(string))
+	}
+
+	destination := inventory.Destination.S3BucketDestination
+	destination.Bucket = aws.String(d.Get("destination.0.bucket").(string))
+	destination.Format = aws.String(d.Get("destination.0.format").(string))
+	if v, ok := d.GetOk("destination.0.prefix"); ok {
+		destination.Prefix = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("filter.0.prefix"); ok {
+		inventory.Filter = &s3.InventoryFilter{Prefix: aws.String(v.(string))}
+	}
+
+	if v, ok := d.GetOk("optional_fields"); ok {
+		inventory.OptionalFields = aws.StringSlice(expandStringSet(v))
+	}
+
+	return inventory
+}
+
+func flattenStorageBucketInventorySchedule(schedule *s3.InventorySchedule) []interface{} {
+	if schedule == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"frequency": aws.StringValue(schedule.Frequency),
+		},
+	}
+}
+
+func flattenStorageBucketInventoryDestination(destination *s3.InventoryDestination) []interface{} {
+	if destination == nil || destination.S3BucketDestination == nil {
+		return nil
+	}
+	dst := destination.S3BucketDestination
+	return []interface{}{
+		map[string]interface{}{
+			"bucket": aws.StringValue(dst.Bucket),
+			"format": aws.StringValue(dst.Format),
+			"prefix": aws.StringValue(dst.Prefix),
+		},
+	}
+}
+
+func flattenStorageBucketInventoryFilter(filter *s3.InventoryFilter) []interface{} {
+	if filter == nil || aws.StringValue(filter.Prefix) == "" {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"prefix": aws.StringValue(filter.Prefix),
+		},
+	}
+}
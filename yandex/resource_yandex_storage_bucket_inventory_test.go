@@ -0,0 +1,92 @@
+package yandex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestStorageBucketInventory(t *testing.T) {
+	s3Client := newTestStorageS3Client(t, map[string]string{"inventory": "NoSuchConfiguration"}, nil)
+	sess, err := session.NewSession(&s3Client.Config)
+	if err != nil {
+		t.Fatalf("failed to create storage session: %s", err)
+	}
+	config := &Config{defaultS3Session: sess}
+	ctx := context.Background()
+
+	resource := resourceYandexStorageBucketInventory()
+	if err := resource.InternalValidate(nil, true); err != nil {
+		t.Fatalf("invalid resource schema: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"bucket":                   "test",
+		"name":                     "weekly",
+		"included_object_versions": "Current",
+		"schedule": []interface{}{
+			map[string]interface{}{"frequency": "Weekly"},
+		},
+		"destination": []interface{}{
+			map[string]interface{}{
+				"bucket": "inventory",
+				"format": "CSV",
+				"prefix": "test/",
+			},
+		},
+		"filter": []interface{}{
+			map[string]interface{}{"prefix": "documents/"},
+		},
+		"optional_fields": []interface{}{"Size", "LastModifiedDate"},
+	})
+	if diags := resource.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to create inventory: %v", diags)
+	}
+	if d.Id() != "test:weekly" {
+		t.Fatalf("unexpected id: %q", d.Id())
+	}
+
+	checks := map[string]string{
+		"bucket":                   "test",
+		"name":                     "weekly",
+		"enabled":                  "true",
+		"included_object_versions": "Current",
+		"schedule.0.frequency":     "Weekly",
+		"destination.0.bucket":     "inventory",
+		"destination.0.format":     "CSV",
+		"destination.0.prefix":     "test/",
+		"filter.0.prefix":          "documents/",
+		"optional_fields.#":        "2",
+	}
+	state := d.State()
+	for key, expected := range checks {
+		if actual := state.Attributes[key]; actual != expected {
+			t.Errorf("unexpected %s: expected %q, got %q", key, expected, actual)
+		}
+	}
+
+	if diags := resource.DeleteContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to delete inventory: %v", diags)
+	}
+	if diags := resource.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to read inventory: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("inventory is not removed from state after delete")
+	}
+}
+
+func TestParseStorageBucketInventoryID(t *testing.T) {
+	bucket, name, err := parseStorageBucketInventoryID("test:weekly")
+	if err != nil || bucket != "test" || name != "weekly" {
+		t.Fatalf("unexpected result: %q, %q, %v", bucket, name, err)
+	}
+
+	for _, id := range []string{"test", ":weekly", "test:"} {
+		if _, _, err := parseStorageBucketInventoryID(id); err == nil {
+			t.Errorf("expected error for id %q", id)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceYandexStorageBucketNotification() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceYandexStorageBucketNotificationPut,
+		ReadContext:   resourceYandexStorageBucketNotificationRead,
+		UpdateContext: resourceYandexStorageBucketNotificationPut,
+		DeleteContext: resourceYandexStorageBucketNotificationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		SchemaVersion: 0,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"access_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"secret_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+
+			"queue": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"queue", "topic"},
+				Elem:         storageBucketNotificationSchema("queue_arn"),
+			},
+
+			"topic": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"queue", "topic"},
+				Elem:         storageBucketNotificationSchema("topic_arn"),
+			},
+		},
+	}
+}
+
+func storageBucketNotificationSchema(arnKey string) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			arnKey: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"events": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Set:      schema.HashString,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(s3.Event_Values(), false),
+				},
+			},
+			"filter_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"filter_suffix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceYandexStorageBucketNotificationPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	s3Client, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	notification := expandStorageBucketNotificationConfiguration(d)
+	if err := putStorageBucketNotificationConfiguration(ctx, s3Client, bucket, notification); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(bucket)
+
+	return resourceYandexStorageBucketNotificationRead(ctx, d, meta)
+}
+
+func resourceYandexStorageBucketNotificationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	s3Client, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	resp, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
+		return s3Client.GetBucketNotificationConfigurationWithContext(ctx, &s3.GetBucketNotificationConfigurationRequest{
+			Bucket: aws.String(d.Id()),
+		})
+	})
+	if err != nil {
+		if handleS3BucketNotFoundError(d, err) {
+			return nil
+		}
+		if isAWSErr(err, "NotImplemented", "") {
+			return diag.Errorf("bucket notifications are not supported by the storage endpoint: %s", err)
+		}
+		return diag.Errorf("error getting Storage Bucket (%s) notification configuration: %s", d.Id(), err)
+	}
+	notification := resp.(*s3.NotificationConfiguration)
+	log.Printf("[DEBUG] Storage get bucket notification configuration output: %#v", notification)
+
+	if err := d.Set("bucket", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	queues, topics := flattenStorageBucketNotificationConfiguration(notification)
+	if err := d.Set("queue", queues); err != nil {
+		return diag.Errorf("error setting queue: %s", err)
+	}
+	if err := d.Set("topic", topics); err != nil {
+		return diag.Errorf("error setting topic: %s", err)
+	}
+
+	return nil
+}
+
+func resourceYandexStorageBucketNotificationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	s3Client, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	// Notifications are removed by putting an empty configuration
+	err = putStorageBucketNotificationConfiguration(ctx, s3Client, d.Id(), &s3.NotificationConfiguration{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func putStorageBucketNotificationConfiguration(ctx context.Context, s3Client *s3.S3, bucket string, notification *s3.NotificationConfiguration) error {
+	log.Printf("[DEBUG] Storage Bucket: %s, put notification configuration: %#v", bucket, notification)
+
+	_, err := retryFlakyS3Responses(ctx, func() (interface{}, error) {
+		return s3Client.PutBucketNotificationConfigurationWithContext(ctx, &s3.PutBucketNotificationConfigurationInput{
+			Bucket:                    aws.String(bucket),
+			NotificationConfiguration: notification,
+		})
+	})
+	if isAWSErr(err, "NotImplemented", "") {
+		return fmt.Errorf("bucket notifications are not supported by the storage endpoint: %s", err)
+	}
+	if err != nil {
+		return fmt.Errorf("error putting Storage Bucket (%s) notification configuration: %s", bucket, err)
+	}
+
+	return nil
+}
+
+func expandStorageBucketNotificationConfiguration(d *schema.ResourceData) *s3.NotificationConfiguration {
+	notification := &s3.NotificationConfiguration{}
+
+	for _, raw := range d.Get("queue").([]interface{}) {
+		q := raw.(map[string]interface{})
+		queue := &s3.QueueConfiguration{
+			QueueArn: aws.String(q["queue_arn"].(string)),
+			Events:   aws.StringSlice(expandStringSet(q["events"])),
+			Filter:   expandStorageBucketNotificationFilter(q),
+		}
+		if id := q["id"].(string); id != "" {
+			queue.Id = aws.String(id)
+		}
+		notification.QueueConfigurations = append(notification.QueueConfigurations, queue)
+	}
+
+	for _, raw := range d.Get("topic").([]interface{}) {
+		t := raw.(map[string]interface{})
+		topic := &s3.TopicConfiguration{
+			TopicArn: aws.String(t["topic_arn"].(string)),
+			Events:   aws.StringSlice(expandStringSet(t["events"])),
+			Filter:   expandStorageBucketNotificationFilter(t),
+		}
+		if id := t["id"].(string); id != "" {
+			topic.Id = aws.String(id)
+		}
+		notification.TopicConfigurations = append(notification.TopicConfigurations, topic)
+	}
+
+	return notification
+}
+
+func expandStorageBucketNotificationFilter(m map[string]interface{}) *s3.NotificationConfigurationFilter {
+	var rules []*s3.FilterRule
+	if prefix := m["filter_prefix"].(string); prefix != "" {
+		rules = append(rules, &s3.FilterRule{Name: aws.String(s3.FilterRuleNamePrefix), Value: aws.String(prefix)})
+	}
+	if suffix := m["filter_suffix"].(string); suffix != "" {
+		rules = append(rules, &s3.FilterRule{Name: aws.String(s3.FilterRuleNameSuffix), Value: aws.String(suffix)})
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	return &s3.NotificationConfigurationFilter{
+		Key: &s3.KeyFilter{FilterRules: rules},
+	}
+}
+
+func flattenStorageBucketNotificationConfiguration(notification *s3.NotificationConfiguration) (queues, topics []map[string]interface{}) {
+	queues = make([]map[string]interface{}, 0, len(notification.QueueConfigurations))
+	for _, queue := range notification.QueueConfigurations {
+		m := flattenStorageBucketNotificationFilter(queue.Filter)
+		m["id"] = aws.StringValue(queue.Id)
+		m["queue_arn"] = aws.StringValue(queue.QueueArn)
+		m["events"] = schema.NewSet(schema.HashString, flattenStringList(queue.Events))
+		queues = append(queues, m)
+	}
+
+	topics = make([]map[string]interface{}, 0, len(notification.TopicConfigurations))
+	for _, topic := range notification.TopicConfigurations {
+		m := flattenStorageBucketNotificationFilter(topic.Filter)
+		m["id"] = aws.StringValue(topic.Id)
+		m["topic_arn"] = aws.StringValue(topic.TopicArn)
+		m["events"] = schema.NewSet(schema.HashString, flattenStringList(topic.Events))
+		topics = append(topics, m)
+	}
+
+	return queues, topics
+}
+
+func flattenStorageBucketNotificationFilter(filter *s3.NotificationConfigurationFilter) map[string]interface{} {
+	m := map[string]interface{}{
+		"filter_prefix": "",
+		"filter_suffix": "",
+	}
+	if filter == nil || filter.Key == nil {
+		return m
+	}
+
+	for _, rule := range filter.Key.FilterRules {
+		switch aws.StringValue(rule.Name) {
+		case s3.FilterRuleNamePrefix:
+			m["filter_prefix"] = aws.StringValue(rule.Value)
+		case s3.FilterRuleNameSuffix:
+			m["filter_suffix"] = aws.StringValue(rule.Value)
+		}
+	}
+	return m
+}
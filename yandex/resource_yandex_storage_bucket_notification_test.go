@@ -0,0 +1,70 @@
+package yandex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestStorageBucketNotification(t *testing.T) {
+	s3Client := newTestStorageS3Client(t, map[string]string{"notification": "NoSuchConfiguration"}, nil)
+	sess, err := session.NewSession(&s3Client.Config)
+	if err != nil {
+		t.Fatalf("failed to create storage session: %s", err)
+	}
+	config := &Config{defaultS3Session: sess}
+	ctx := context.Background()
+
+	resource := resourceYandexStorageBucketNotification()
+	if err := resource.InternalValidate(nil, true); err != nil {
+		t.Fatalf("invalid resource schema: %s", err)
+	}
+
+	queueArn := "yrn:yc:ymq:ru-central1:b1gexample:queue"
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"bucket": "test",
+		"queue": []interface{}{
+			map[string]interface{}{
+				"id":            "images",
+				"queue_arn":     queueArn,
+				"events":        []interface{}{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"},
+				"filter_prefix": "images/",
+				"filter_suffix": ".png",
+			},
+		},
+	})
+	if diags := resource.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to create notification: %v", diags)
+	}
+	if d.Id() != "test" {
+		t.Fatalf("unexpected id: %q", d.Id())
+	}
+
+	checks := map[string]string{
+		"queue.#":               "1",
+		"queue.0.id":            "images",
+		"queue.0.queue_arn":     queueArn,
+		"queue.0.events.#":      "2",
+		"queue.0.filter_prefix": "images/",
+		"queue.0.filter_suffix": ".png",
+		"topic.#":               "0",
+	}
+	state := d.State()
+	for key, expected := range checks {
+		if actual := state.Attributes[key]; actual != expected {
+			t.Errorf("unexpected %s: expected %q, got %q", key, expected, actual)
+		}
+	}
+
+	if diags := resource.DeleteContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to delete notification: %v", diags)
+	}
+	if diags := resource.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to read notification: %v", diags)
+	}
+	if queues := d.Get("queue").([]interface{}); len(queues) != 0 {
+		t.Fatalf("notification is not deleted: %#v", queues)
+	}
+}
@@ -5,16 +5,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -983,6 +989,191 @@ func TestStorageBucketName(t *testing.T) {
 	}
 }
 
+func TestStorageBucketReplicationConfiguration(t *testing.T) {
+	s3Client := newTestStorageS3Client(t, map[string]string{
+		"replication": "ReplicationConfigurationNotFoundError",
+	}, nil)
+	ctx := context.Background()
+
+	rule := map[string]interface{}{
+		"id":       "rule-1",
+		"priority": 1,
+		"status":   s3.ReplicationRuleStatusEnabled,
+		"filter": []interface{}{
+			map[string]interface{}{"prefix": "logs/"},
+		},
+		"destination": []interface{}{
+			map[string]interface{}{"bucket": "target", "storage_class": storageClassCold},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceYandexStorageBucket().Schema, map[string]interface{}{
+		"bucket": "source",
+		"replication_configuration": []interface{}{
+			map[string]interface{}{"rules": []interface{}{rule}},
+		},
+	})
+	if err := resourceYandexStorageBucketReplicationConfigurationUpdate(ctx, s3Client, d); err != nil {
+		t.Fatalf("failed to put replication configuration: %s", err)
+	}
+
+	replication, err := readStorageBucketReplicationConfiguration(ctx, s3Client, "source")
+	if err != nil {
+		t.Fatalf("failed to read replication configuration: %s", err)
+	}
+	if err := d.Set("replication_configuration", replication); err != nil {
+		t.Fatalf("failed to set replication configuration: %s", err)
+	}
+	expected := map[string]interface{}{
+		"id":       "rule-1",
+		"priority": 1,
+		"status":   s3.ReplicationRuleStatusEnabled,
+		"filter": []interface{}{
+			map[string]interface{}{"prefix": "logs/", "tags": map[string]interface{}{}},
+		},
+		"destination": []interface{}{
+			map[string]interface{}{"bucket": "target", "storage_class": storageClassCold},
+		},
+		"delete_marker_replication_status": "",
+	}
+	if actual := d.Get("replication_configuration.0.rules.0"); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("unexpected replication rule read back:\nexpected: %#v\nactual:   %#v", expected, actual)
+	}
+
+	// Configuration changed outside of Terraform is detected on read
+	_, err = s3Client.PutBucketReplicationWithContext(ctx, &s3.PutBucketReplicationInput{
+		Bucket: aws.String("source"),
+		ReplicationConfiguration: expandStorageBucketReplicationConfiguration(map[string]interface{}{
+			"role": "",
+			"rules": []interface{}{map[string]interface{}{
+				"id":                               "rule-1",
+				"priority":                         1,
+				"status":                           s3.ReplicationRuleStatusDisabled,
+				"filter":                           []interface{}{},
+				"destination":                      []interface{}{map[string]interface{}{"bucket": "other", "storage_class": ""}},
+				"delete_marker_replication_status": "",
+			}},
+		}),
+	}, withoutStorageBucketReplicationRole)
+	if err != nil {
+		t.Fatalf("failed to put replication configuration: %s", err)
+	}
+	replication, err = readStorageBucketReplicationConfiguration(ctx, s3Client, "source")
+	if err != nil {
+		t.Fatalf("failed to read replication configuration: %s", err)
+	}
+	drifted := replication[0]["rules"].([]interface{})[0].(map[string]interface{})
+	if drifted["status"] != s3.ReplicationRuleStatusDisabled || drifted["filter"] != nil ||
+		drifted["destination"].([]interface{})[0].(map[string]interface{})["bucket"] != "other" {
+		t.Fatalf("replication configuration drift is not detected: %#v", drifted)
+	}
+
+	// Removing the block deletes the configuration
+	d = schema.TestResourceDataRaw(t, resourceYandexStorageBucket().Schema, map[string]interface{}{
+		"bucket": "source",
+	})
+	if err := resourceYandexStorageBucketReplicationConfigurationUpdate(ctx, s3Client, d); err != nil {
+		t.Fatalf("failed to delete replication configuration: %s", err)
+	}
+	replication, err = readStorageBucketReplicationConfiguration(ctx, s3Client, "source")
+	if err != nil {
+		t.Fatalf("failed to read replication configuration: %s", err)
+	}
+	if len(replication) != 0 {
+		t.Fatalf("replication configuration is not deleted: %#v", replication)
+	}
+}
+
+func TestExpandStorageBucketReplicationConfigurationRole(t *testing.T) {
+	rules := []interface{}{map[string]interface{}{
+		"id":                               "",
+		"priority":                         0,
+		"status":                           s3.ReplicationRuleStatusEnabled,
+		"filter":                           []interface{}{},
+		"destination":                      []interface{}{map[string]interface{}{"bucket": "target", "storage_class": ""}},
+		"delete_marker_replication_status": "",
+	}}
+
+	// Empty role is not sent, so that the server doesn't reject it
+	rc := expandStorageBucketReplicationConfiguration(map[string]interface{}{"role": "", "rules": rules})
+	if rc.Role != nil {
+		t.Fatalf("empty role is sent: %q", aws.StringValue(rc.Role))
+	}
+	rc = expandStorageBucketReplicationConfiguration(map[string]interface{}{"role": "role", "rules": rules})
+	if aws.StringValue(rc.Role) != "role" {
+		t.Fatalf("unexpected role: %q", aws.StringValue(rc.Role))
+	}
+}
+
+// newTestStorageS3Client returns a client of a local S3-compatible stand-in, which stores bucket configurations
+// put as subresources ("?cors", "?replication", etc.) and returns them back. notFoundCodes maps the subresources
+// to the error codes returned when there is no such configuration. Other requests are served by objects handler,
+// if it is not nil.
+func newTestStorageS3Client(t *testing.T, notFoundCodes map[string]string, objects http.HandlerFunc) *s3.S3 {
+	var mu sync.Mutex
+	configurations := make(map[string][]byte)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var subresource string
+		for name := range notFoundCodes {
+			if r.URL.Query().Has(name) {
+				subresource = name
+			}
+		}
+		if subresource == "" && objects != nil {
+			objects(w, r)
+			return
+		}
+		if subresource == "" {
+			w.WriteHeader(http.StatusNotImplemented)
+			fmt.Fprint(w, "<Error><Code>NotImplemented</Code></Error>")
+			return
+		}
+		key := r.URL.Path + "?" + subresource
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			configurations[key] = body
+		case http.MethodGet:
+			body, ok := configurations[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, "<Error><Code>%s</Code></Error>", notFoundCodes[subresource])
+				return
+			}
+			w.Write(body)
+		case http.MethodDelete:
+			delete(configurations, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	subcheckInterval, checkInterval := waitConditionStableSubcheckInterval, waitConditionStableCheckInterval
+	waitConditionStableSubcheckInterval, waitConditionStableCheckInterval = time.Millisecond, time.Millisecond
+	t.Cleanup(func() {
+		waitConditionStableSubcheckInterval, waitConditionStableCheckInterval = subcheckInterval, checkInterval
+	})
+
+	sess, err := session.NewSession(&aws.Config{
+		Credentials:      credentials.NewStaticCredentials("access-key", "secret-key", ""),
+		Endpoint:         aws.String(server.URL),
+		Region:           aws.String(defaultS3Region),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("failed to create storage session: %s", err)
+	}
+	return s3.New(sess)
+}
+
 func testAccCheckStorageBucketDestroy(s *terraform.State) error {
 	return testAccCheckStorageBucketDestroyWithProvider(s, testAccProvider)
 }
@@ -1000,7 +1191,7 @@ func testAccCheckStorageBucketDestroyWithProvider(s *terraform.State, provider *
 
 	check := func(rs *terraform.ResourceState) error {
 		// access and secret keys should be destroyed too and defaults may be not provided, so create temporary ones
-		ak, sak, cleanup, err := createTemporaryStaticAccessKey("editor", config)
+		ak, sak, cleanup, err := createTemporaryStaticAccessKey(context.Background(), "editor", config)
 		if err != nil {
 			return err
 		}
@@ -1508,6 +1699,12 @@ func (b testAccStorageBucketConfigBuilder) before(statement string) testAccStora
 	return b
 }
 
+func (b testAccStorageBucketConfigBuilder) after(statement string) testAccStorageBucketConfigBuilder {
+	b.afterBucket = append(b.afterBucket, statement)
+
+	return b
+}
+
 func (b testAccStorageBucketConfigBuilder) asEditor() testAccStorageBucketConfigBuilder {
 	b.role = testAccStorageBucketConfigBuilderRoleEditor
 
@@ -0,0 +1,492 @@
+package yandex
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+const (
+	storageDirectoryDefaultParallelism = 10
+	storageDirectoryDefaultContentType = "application/octet-stream"
+	// Maximum number of keys in a single DeleteObjects request
+	storageDirectoryDeleteBatchSize = 1000
+)
+
+func resourceYandexStorageDirectory() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceYandexStorageDirectoryCreate,
+		ReadContext:   resourceYandexStorageDirectoryRead,
+		UpdateContext: resourceYandexStorageDirectoryUpdate,
+		DeleteContext: resourceYandexStorageDirectoryDelete,
+
+		CustomizeDiff: resourceYandexStorageDirectoryCustomizeDiff,
+
+		SchemaVersion: 0,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"key_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"source_dir": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"access_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"secret_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+
+			"acl": {
+				Type:     schema.TypeString,
+				Default:  "private",
+				Optional: true,
+			},
+
+			"delete_removed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      storageDirectoryDefaultParallelism,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+
+			"files": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// storageDirectoryFile is a local file, which is uploaded as the object with the key.
+type storageDirectoryFile struct {
+	key         string
+	path        string
+	contentType string
+}
+
+// resourceYandexStorageDirectoryCustomizeDiff computes hashes of the local files, so that the plan shows
+// the keys of added, changed and removed objects only, as a diff of the "files" map.
+func resourceYandexStorageDirectoryCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("source_dir") || !d.NewValueKnown("key_prefix") {
+		return d.SetNewComputed("files")
+	}
+
+	files, err := readStorageDirectory(d.Get("source_dir").(string), d.Get("key_prefix").(string))
+	if err != nil {
+		return err
+	}
+
+	hashes, err := hashStorageDirectoryFiles(files)
+	if err != nil {
+		return err
+	}
+
+	// All the objects are uploaded again to apply the new ACL
+	if d.HasChange("acl") && d.Id() != "" {
+		return d.SetNewComputed("files")
+	}
+
+	old, _ := d.GetChange("files")
+	added, changed, removed := diffStorageDirectoryFiles(old.(map[string]interface{}), hashes)
+	if len(added)+len(changed)+len(removed) == 0 {
+		return nil
+	}
+	log.Printf("[DEBUG] Storage directory %s: %d objects to add, %d to change, %d to remove",
+		d.Get("source_dir").(string), len(added), len(changed), len(removed))
+
+	return d.SetNew("files", hashes)
+}
+
+func resourceYandexStorageDirectoryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// ID is set before the upload, so that the objects uploaded before a failure are recorded in the state
+	d.SetId(d.Get("bucket").(string) + "/" + d.Get("key_prefix").(string))
+
+	if err := syncStorageDirectory(ctx, d, meta, map[string]interface{}{}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceYandexStorageDirectoryRead(ctx, d, meta)
+}
+
+func resourceYandexStorageDirectoryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	s3conn, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	prefix := d.Get("key_prefix").(string)
+
+	existing := make(map[string]bool)
+	err = s3conn.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			existing[aws.StringValue(object.Key)] = true
+		}
+		return true
+	})
+	if err != nil {
+		if isAWSErr(err, s3.ErrCodeNoSuchBucket, "") {
+			log.Printf("[WARN] Storage Bucket (%s) not found, removing directory %s from state", bucket, d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error listing objects in bucket %q: %s", bucket, err)
+	}
+
+	// Objects deleted outside of Terraform are removed from the state, so that they are uploaded again.
+	// Content of the objects is not compared, since ETag is not MD5 hash for encrypted objects.
+	files := make(map[string]interface{})
+	for key, hash := range d.Get("files").(map[string]interface{}) {
+		if existing[key] {
+			files[key] = hash
+		}
+	}
+	if err := d.Set("files", files); err != nil {
+		return diag.Errorf("error setting files: %s", err)
+	}
+
+	return nil
+}
+
+func resourceYandexStorageDirectoryUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	old, _ := d.GetChange("files")
+	uploaded := old.(map[string]interface{})
+	if d.HasChange("acl") {
+		uploaded = map[string]interface{}{}
+	}
+
+	if err := syncStorageDirectory(ctx, d, meta, uploaded); err != nil {
+		if d.HasChange("acl") {
+			// Objects left with the old ACL are not tracked in "files", so the previous state is kept to sync all of them again
+			d.Partial(true)
+		}
+		return diag.FromErr(err)
+	}
+
+	return resourceYandexStorageDirectoryRead(ctx, d, meta)
+}
+
+func resourceYandexStorageDirectoryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	s3conn, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return diag.Errorf("error getting storage client: %s", err)
+	}
+
+	keys := make([]string, 0, len(d.Get("files").(map[string]interface{})))
+	for key := range d.Get("files").(map[string]interface{}) {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if err := deleteStorageDirectoryObjects(ctx, s3conn, d.Get("bucket").(string), keys); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// syncStorageDirectory uploads the local files, which are not in the uploaded ones or have changed since then,
+// and deletes the removed ones, if it is requested. Hashes of the files are set to the "files" attribute.
+// On failure, "files" is set to hashes of the objects actually synced, since otherwise the planned ones are saved
+// to the state, so that the rest of the files is synced on the next apply.
+func syncStorageDirectory(ctx context.Context, d *schema.ResourceData, meta interface{}, uploaded map[string]interface{}) (err error) {
+	synced := make(map[string]interface{}, len(uploaded))
+	for key, hash := range uploaded {
+		synced[key] = hash
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if setErr := d.Set("files", synced); setErr != nil {
+			log.Printf("[WARN] Error setting files of storage directory %q: %s", d.Id(), setErr)
+		}
+	}()
+
+	config := meta.(*Config)
+	s3conn, err := getS3Client(ctx, d, config)
+	if err != nil {
+		return fmt.Errorf("error getting storage client: %s", err)
+	}
+
+	bucket := d.Get("bucket").(string)
+	files, err := readStorageDirectory(d.Get("source_dir").(string), d.Get("key_prefix").(string))
+	if err != nil {
+		return err
+	}
+
+	hashes, err := hashStorageDirectoryFiles(files)
+	if err != nil {
+		return err
+	}
+
+	added, changed, removed := diffStorageDirectoryFiles(uploaded, hashes)
+	log.Printf("[DEBUG] Syncing storage directory to bucket %q: %d objects to add, %d to change, %d to remove",
+		bucket, len(added), len(changed), len(removed))
+
+	toUpload := make([]storageDirectoryFile, 0, len(added)+len(changed))
+	for _, key := range append(added, changed...) {
+		toUpload = append(toUpload, files[key])
+	}
+	uploadedKeys, err := uploadStorageDirectoryFiles(ctx, s3conn, bucket, d.Get("acl").(string), d.Get("parallelism").(int), toUpload)
+	for _, key := range uploadedKeys {
+		synced[key] = hashes[key]
+	}
+	if err != nil {
+		return err
+	}
+
+	if d.Get("delete_removed").(bool) {
+		if err := deleteStorageDirectoryObjects(ctx, s3conn, bucket, removed); err != nil {
+			return err
+		}
+	}
+
+	return d.Set("files", hashes)
+}
+
+// readStorageDirectory returns regular files of the directory and its subdirectories by their object keys.
+func readStorageDirectory(sourceDir, prefix string) (map[string]storageDirectoryFile, error) {
+	root, err := homedir.Expand(sourceDir)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding homedir in source_dir (%s): %s", sourceDir, err)
+	}
+
+	files := make(map[string]storageDirectoryFile)
+	err = filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		key := prefix + filepath.ToSlash(rel)
+		files[key] = storageDirectoryFile{
+			key:         key,
+			path:        p,
+			contentType: storageDirectoryContentType(key),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading source_dir (%s): %s", sourceDir, err)
+	}
+
+	return files, nil
+}
+
+func hashStorageDirectoryFiles(files map[string]storageDirectoryFile) (map[string]interface{}, error) {
+	hashes := make(map[string]interface{}, len(files))
+	for key, file := range files {
+		hash, err := storageDirectoryFileHash(file.path)
+		if err != nil {
+			return nil, err
+		}
+		hashes[key] = hash
+	}
+	return hashes, nil
+}
+
+// storageDirectoryFileHash returns MD5 hash of the file, the same as filemd5() does.
+func storageDirectoryFileHash(p string) (string, error) {
+	file, err := os.Open(p)
+	if err != nil {
+		return "", fmt.Errorf("error opening file (%s): %s", p, err)
+	}
+	defer file.Close()
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("error reading file (%s): %s", p, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// storageDirectoryContentType guesses content type of the object by extension of its key.
+func storageDirectoryContentType(key string) string {
+	if contentType := mime.TypeByExtension(strings.ToLower(path.Ext(key))); contentType != "" {
+		return contentType
+	}
+	return storageDirectoryDefaultContentType
+}
+
+// diffStorageDirectoryFiles returns sorted keys of added, changed and removed files.
+func diffStorageDirectoryFiles(old, new map[string]interface{}) (added, changed, removed []string) {
+	for key, hash := range new {
+		oldHash, ok := old[key]
+		switch {
+		case !ok:
+			added = append(added, key)
+		case oldHash != hash:
+			changed = append(changed, key)
+		}
+	}
+	for key := range old {
+		if _, ok := new[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(changed)
+	sort.Strings(removed)
+	return added, changed, removed
+}
+
+// uploadStorageDirectoryFiles uploads the files in parallel and returns keys of the uploaded ones.
+// Uploads in progress are cancelled on the first error.
+func uploadStorageDirectoryFiles(ctx context.Context, s3conn *s3.S3, bucket, acl string, parallelism int, files []storageDirectoryFile) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		uploaded []string
+		firstErr error
+	)
+	semaphore := make(chan struct{}, parallelism)
+
+	for _, file := range files {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(file storageDirectoryFile) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			err := uploadStorageDirectoryFile(ctx, s3conn, bucket, acl, file)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			uploaded = append(uploaded, file.key)
+		}(file)
+	}
+	wg.Wait()
+
+	if firstErr == nil && ctx.Err() != nil {
+		firstErr = ctx.Err()
+	}
+	sort.Strings(uploaded)
+	return uploaded, firstErr
+}
+
+func uploadStorageDirectoryFile(ctx context.Context, s3conn *s3.S3, bucket, acl string, file storageDirectoryFile) error {
+	body, err := os.Open(file.path)
+	if err != nil {
+		return fmt.Errorf("error opening file (%s): %s", file.path, err)
+	}
+	defer body.Close()
+
+	log.Printf("[DEBUG] Uploading %s to storage object %q in bucket %q", file.path, file.key, bucket)
+	_, err = s3conn.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(file.key),
+		ACL:         aws.String(acl),
+		ContentType: aws.String(file.contentType),
+		Body:        body,
+	})
+	if err != nil {
+		return fmt.Errorf("error putting object %q in bucket %q: %s", file.key, bucket, err)
+	}
+	return nil
+}
+
+func deleteStorageDirectoryObjects(ctx context.Context, s3conn *s3.S3, bucket string, keys []string) error {
+	for start := 0; start < len(keys); start += storageDirectoryDeleteBatchSize {
+		end := start + storageDirectoryDeleteBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		objects := make([]*s3.ObjectIdentifier, 0, end-start)
+		for _, key := range keys[start:end] {
+			objects = append(objects, &s3.ObjectIdentifier{Key: aws.String(key)})
+		}
+
+		log.Printf("[DEBUG] Deleting %d objects from bucket %q", len(objects), bucket)
+		resp, err := s3conn.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &s3.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("error deleting objects from bucket %q: %s", bucket, err)
+		}
+		if len(resp.Errors) > 0 {
+			e := resp.Errors[0]
+			return fmt.Errorf("error deleting object %q from bucket %q: %s", aws.StringValue(e.Key), bucket, aws.StringValue(e.Message))
+		}
+	}
+
+	return nil
+}
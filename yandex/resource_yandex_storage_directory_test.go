@@ -0,0 +1,203 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestReadStorageDirectory(t *testing.T) {
+	dir := t.TempDir()
+	testStorageDirectoryWriteFile(t, dir, "index.html", "<html></html>")
+	testStorageDirectoryWriteFile(t, dir, "css/style.CSS", "body {}")
+	testStorageDirectoryWriteFile(t, dir, "data/blob", "blob")
+
+	files, err := readStorageDirectory(dir, "site/")
+	if err != nil {
+		t.Fatalf("failed to read directory: %s", err)
+	}
+
+	expected := map[string]storageDirectoryFile{
+		"site/index.html": {
+			key:         "site/index.html",
+			path:        filepath.Join(dir, "index.html"),
+			contentType: "text/html; charset=utf-8",
+		},
+		"site/css/style.CSS": {
+			key:         "site/css/style.CSS",
+			path:        filepath.Join(dir, "css", "style.CSS"),
+			contentType: "text/css; charset=utf-8",
+		},
+		"site/data/blob": {
+			key:         "site/data/blob",
+			path:        filepath.Join(dir, "data", "blob"),
+			contentType: storageDirectoryDefaultContentType,
+		},
+	}
+	if !reflect.DeepEqual(expected, files) {
+		t.Fatalf("unexpected files:\nexpected: %#v\nactual:   %#v", expected, files)
+	}
+
+	hashes, err := hashStorageDirectoryFiles(files)
+	if err != nil {
+		t.Fatalf("failed to hash files: %s", err)
+	}
+	// md5 -s "blob"
+	if hash := hashes["site/data/blob"]; hash != "ee26908bf9629eeb4b37dac350f4754a" {
+		t.Fatalf("unexpected hash of the file: %s", hash)
+	}
+}
+
+func TestDiffStorageDirectoryFiles(t *testing.T) {
+	old := map[string]interface{}{
+		"a": "1",
+		"b": "2",
+		"c": "3",
+	}
+	new := map[string]interface{}{
+		"a": "1",
+		"b": "20",
+		"d": "4",
+		"e": "5",
+	}
+
+	added, changed, removed := diffStorageDirectoryFiles(old, new)
+	if !reflect.DeepEqual([]string{"d", "e"}, added) {
+		t.Errorf("unexpected added files: %v", added)
+	}
+	if !reflect.DeepEqual([]string{"b"}, changed) {
+		t.Errorf("unexpected changed files: %v", changed)
+	}
+	if !reflect.DeepEqual([]string{"c"}, removed) {
+		t.Errorf("unexpected removed files: %v", removed)
+	}
+}
+
+func TestSyncStorageDirectoryFailure(t *testing.T) {
+	dir := t.TempDir()
+	testStorageDirectoryWriteFile(t, dir, "a", "a")
+	testStorageDirectoryWriteFile(t, dir, "b", "b")
+	testStorageDirectoryWriteFile(t, dir, "c", "c")
+
+	var (
+		mu  sync.Mutex
+		put []string
+	)
+	s3Client := newTestStorageS3Client(t, map[string]string{}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotImplemented)
+			fmt.Fprint(w, "<Error><Code>NotImplemented</Code></Error>")
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		put = append(put, path.Base(r.URL.Path))
+		if path.Base(r.URL.Path) == "b" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, "<Error><Code>AccessDenied</Code></Error>")
+		}
+	})
+	sess, err := session.NewSession(&s3Client.Config)
+	if err != nil {
+		t.Fatalf("failed to create storage session: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceYandexStorageDirectory().Schema, map[string]interface{}{
+		"bucket":      "test",
+		"source_dir":  dir,
+		"parallelism": 1,
+	})
+	uploaded := map[string]interface{}{"old": "hash"}
+	if err := syncStorageDirectory(context.Background(), d, &Config{defaultS3Session: sess}, uploaded); err == nil {
+		t.Fatal("expected sync to fail")
+	}
+
+	if !reflect.DeepEqual([]string{"a", "b"}, put) {
+		t.Errorf("uploads are not stopped on the first error: %v", put)
+	}
+	// md5 -s "a"
+	expected := map[string]interface{}{"old": "hash", "a": "0cc175b9c0f1b6a831c399e269772661"}
+	if files := d.Get("files"); !reflect.DeepEqual(expected, files) {
+		t.Errorf("unexpected files recorded: %v", files)
+	}
+}
+
+func TestAccStorageDirectory_basic(t *testing.T) {
+	resourceName := "yandex_storage_directory.test"
+	rInt := acctest.RandInt()
+
+	dir := t.TempDir()
+	testStorageDirectoryWriteFile(t, dir, "index.html", "<html></html>")
+	testStorageDirectoryWriteFile(t, dir, "css/style.css", "body {}")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStorageObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageDirectoryConfig(rInt, dir),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "files.%", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "files.site/index.html"),
+					resource.TestCheckResourceAttrSet(resourceName, "files.site/css/style.css"),
+				),
+			},
+			{
+				PreConfig: func() {
+					testStorageDirectoryWriteFile(t, dir, "index.html", "<html><body></body></html>")
+					testStorageDirectoryWriteFile(t, dir, "js/app.js", "main()")
+					if err := os.Remove(filepath.Join(dir, "css", "style.css")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccStorageDirectoryConfig(rInt, dir),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "files.%", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "files.site/index.html"),
+					resource.TestCheckResourceAttrSet(resourceName, "files.site/js/app.js"),
+				),
+			},
+		},
+	})
+}
+
+func testAccStorageDirectoryConfig(randInt int, dir string) string {
+	bucketConfig := newBucketConfigBuilder(randInt).asEditor().render()
+
+	directoryConfig := fmt.Sprintf(`
+resource "yandex_storage_directory" "test" {
+	bucket = yandex_storage_bucket.test.bucket
+
+	access_key = yandex_iam_service_account_static_access_key.sa-key.access_key
+	secret_key = yandex_iam_service_account_static_access_key.sa-key.secret_key
+
+	source_dir     = "%s"
+	key_prefix     = "site/"
+	delete_removed = true
+}
+`, dir)
+
+	return bucketConfig + directoryConfig
+}
+
+func testStorageDirectoryWriteFile(t *testing.T, dir, name, content string) {
+	p := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
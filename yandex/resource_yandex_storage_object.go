@@ -14,6 +14,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -27,6 +28,8 @@ func resourceYandexStorageObject() *schema.Resource {
 		UpdateContext: resourceYandexStorageObjectUpdate,
 		DeleteContext: resourceYandexStorageObjectDelete,
 
+		CustomizeDiff: resourceYandexStorageObjectCustomizeDiff,
+
 		SchemaVersion: 0,
 
 		Schema: map[string]*schema.Schema{
@@ -110,6 +113,32 @@ func resourceYandexStorageObject() *schema.Resource {
 				RequiredWith: []string{"object_lock_mode"},
 				ValidateFunc: validation.IsRFC3339Time,
 			},
+
+			// Objects encrypted by the default encryption of the bucket report its key too, so the key is kept
+			// in state, when it is removed from the config, and the object isn't encrypted again.
+			"kms_key_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"part_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(int(s3manager.MinUploadPartSize)),
+			},
+
+			"upload_concurrency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 64),
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
@@ -165,7 +194,7 @@ func resourceYandexStorageObjectCreate(ctx context.Context, d *schema.ResourceDa
 
 	awsbucket := aws.String(bucket)
 	awskey := aws.String(key)
-	putObjectInput := &s3.PutObjectInput{
+	uploadInput := &s3manager.UploadInput{
 		Bucket: awsbucket,
 		Key:    awskey,
 		ACL:    aws.String(d.Get("acl").(string)),
@@ -173,12 +202,17 @@ func resourceYandexStorageObjectCreate(ctx context.Context, d *schema.ResourceDa
 	}
 
 	if v, ok := d.GetOk("content_type"); ok {
-		putObjectInput.ContentType = aws.String(v.(string))
+		uploadInput.ContentType = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("kms_key_id"); ok {
+		uploadInput.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAwsKms)
+		uploadInput.SSEKMSKeyId = aws.String(v.(string))
 	}
 
 	if v, ok := d.GetOk("object_lock_legal_hold_status"); ok {
 		status := v.(string)
-		putObjectInput.SetObjectLockLegalHoldStatus(status)
+		uploadInput.ObjectLockLegalHoldStatus = aws.String(status)
 	}
 
 	if v, ok := d.GetOk("object_lock_mode"); ok {
@@ -186,13 +220,26 @@ func resourceYandexStorageObjectCreate(ctx context.Context, d *schema.ResourceDa
 		v = d.Get("object_lock_retain_until_date")
 		// ignore error because the schema has validated the string already
 		untilDate, _ := time.Parse(time.RFC3339, v.(string))
-		putObjectInput.SetObjectLockMode(mode)
-		putObjectInput.SetObjectLockRetainUntilDate(untilDate)
+		uploadInput.ObjectLockMode = aws.String(mode)
+		uploadInput.ObjectLockRetainUntilDate = aws.Time(untilDate)
 	}
 
-	log.Printf("[DEBUG] Sending putObjectInput %s", putObjectInput.String())
+	// Sources larger than the part size are uploaded by parts, which are read and sent concurrently,
+	// so the whole source is never kept in memory. Smaller ones are sent with a single PUT.
+	uploader := s3manager.NewUploaderWithClient(s3conn, func(u *s3manager.Uploader) {
+		if v, ok := d.GetOk("part_size"); ok {
+			u.PartSize = int64(v.(int))
+		}
+		if v, ok := d.GetOk("upload_concurrency"); ok {
+			u.Concurrency = v.(int)
+		}
+		// Abort multipart upload on failure, so that uploaded parts are not left in the bucket
+		u.LeavePartsOnError = false
+	})
+
+	log.Printf("[DEBUG] Uploading storage object %q to bucket %q with part size %d", key, bucket, uploader.PartSize)
 
-	if _, err := s3conn.PutObjectWithContext(ctx, putObjectInput); err != nil {
+	if _, err := uploader.UploadWithContext(ctx, uploadInput); err != nil {
 		return diag.Errorf("error putting object in bucket %q: %s", bucket, err)
 	}
 
@@ -250,6 +297,9 @@ func resourceYandexStorageObjectRead(ctx context.Context, d *schema.ResourceData
 	log.Printf("[DEBUG] Reading storage object meta: %s", resp)
 
 	d.Set("content_type", resp.ContentType)
+	d.Set("kms_key_id", resp.SSEKMSKeyId)
+	// ETag of the object uploaded by parts is not MD5 hash of its content
+	d.Set("etag", strings.Trim(aws.StringValue(resp.ETag), `"`))
 
 	if resp.ObjectLockLegalHoldStatus != nil {
 		status := aws.StringValue(resp.ObjectLockLegalHoldStatus)
@@ -320,20 +370,27 @@ func resourceYandexStorageObjectUpdate(ctx context.Context, d *schema.ResourceDa
 	return nil
 }
 
+// storageObjectContentKeys are the attributes, changes of which upload the object again.
+var storageObjectContentKeys = []string{
+	"source",
+	"source_hash",
+	"content",
+	"content_base64",
+	"content_type",
+	"kms_key_id",
+}
+
 func hasObjectContentChanged(d *schema.ResourceData) bool {
-	for _, key := range []string{
-		"source",
-		"source_hash",
-		"content",
-		"content_base64",
-		"content_type",
-	} {
-		if d.HasChange(key) {
-			return true
-		}
-	}
+	return d.HasChanges(storageObjectContentKeys...)
+}
 
-	return false
+// resourceYandexStorageObjectCustomizeDiff marks etag unknown, when the object is uploaded again. The etag is
+// informational and isn't used to detect changes, because it is not an MD5 hash of the content uploaded by parts.
+func resourceYandexStorageObjectCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" && d.HasChanges(storageObjectContentKeys...) {
+		return d.SetNewComputed("etag")
+	}
+	return nil
 }
 
 func resourceYandexStorageObjectACLUpdate(ctx context.Context, s3conn *s3.S3, d *schema.ResourceData) error {
@@ -5,17 +5,23 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	terraform2 "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -424,7 +430,7 @@ func testAccCheckStorageObjectDestroyWithProvider(s *terraform.State, provider *
 	config := provider.Meta().(*Config)
 
 	// access and secret keys should be destroyed too and defaults may be not provided, so create temporary ones
-	ak, sak, cleanup, err := createTemporaryStaticAccessKey("editor", config)
+	ak, sak, cleanup, err := createTemporaryStaticAccessKey(context.Background(), "editor", config)
 	if err != nil {
 		return err
 	}
@@ -827,3 +833,115 @@ func checkErrorSkipNotImplemented(t *testing.T) func(error) error {
 		return err
 	}
 }
+
+func TestStorageObjectEtagDiff(t *testing.T) {
+	r := resourceYandexStorageObject()
+	state := map[string]interface{}{
+		"bucket":  "bucket",
+		"key":     "key",
+		"content": "content",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, state)
+	d.SetId("key")
+	d.Set("etag", "9a0364b9e99bb480dd25e1f0284c8555")
+
+	for name, tc := range map[string]struct {
+		config   map[string]interface{}
+		computed bool
+	}{
+		"content changed": {config: map[string]interface{}{"bucket": "bucket", "key": "key", "content": "changed"}, computed: true},
+		"acl changed":     {config: map[string]interface{}{"bucket": "bucket", "key": "key", "content": "content", "acl": "public-read"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diff, err := r.Diff(context.Background(), d.State(), terraform2.NewResourceConfigRaw(tc.config), nil)
+			if err != nil {
+				t.Fatalf("failed to diff: %s", err)
+			}
+			etag, ok := diff.Attributes["etag"]
+			if computed := ok && etag.NewComputed; computed != tc.computed {
+				t.Errorf("etag is computed: %t, expected: %t", computed, tc.computed)
+			}
+		})
+	}
+}
+
+func TestStorageObjectMultipartUpload(t *testing.T) {
+	for name, failPart := range map[string]int{"completed": 0, "aborted": 2} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				mu                 sync.Mutex
+				parts              = make(map[string]int)
+				completed, aborted bool
+			)
+			s3Client := newTestStorageS3Client(t, map[string]string{}, func(w http.ResponseWriter, r *http.Request) {
+				query := r.URL.Query()
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch {
+				case r.Method == http.MethodPost && query.Has("uploads"):
+					fmt.Fprint(w, "<InitiateMultipartUploadResult><UploadId>upload-id</UploadId></InitiateMultipartUploadResult>")
+				case r.Method == http.MethodPut && query.Has("partNumber"):
+					body, _ := io.ReadAll(r.Body)
+					if query.Get("partNumber") == strconv.Itoa(failPart) {
+						w.WriteHeader(http.StatusBadRequest)
+						fmt.Fprint(w, "<Error><Code>InvalidPart</Code></Error>")
+						return
+					}
+					parts[query.Get("partNumber")] = len(body)
+					w.Header().Set("ETag", `"part"`)
+				case r.Method == http.MethodPost && query.Has("uploadId"):
+					completed = true
+					fmt.Fprint(w, `<CompleteMultipartUploadResult><ETag>"multipart-2"</ETag></CompleteMultipartUploadResult>`)
+				case r.Method == http.MethodDelete && query.Has("uploadId"):
+					aborted = true
+					w.WriteHeader(http.StatusNoContent)
+				case r.Method == http.MethodHead:
+					w.Header().Set("ETag", `"multipart-2"`)
+					w.Header().Set("Content-Type", "application/octet-stream")
+				case r.Method == http.MethodGet && query.Has("tagging"):
+					fmt.Fprint(w, "<Tagging><TagSet></TagSet></Tagging>")
+				default:
+					w.WriteHeader(http.StatusNotImplemented)
+					fmt.Fprint(w, "<Error><Code>NotImplemented</Code></Error>")
+				}
+			})
+			sess, err := session.NewSession(&s3Client.Config)
+			if err != nil {
+				t.Fatalf("failed to create storage session: %s", err)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceYandexStorageObject().Schema, map[string]interface{}{
+				"bucket":             "test",
+				"key":                "large-object",
+				"content":            strings.Repeat("a", 6<<20),
+				"part_size":          5 << 20,
+				"upload_concurrency": 1,
+			})
+			diags := resourceYandexStorageObjectCreate(context.Background(), d, &Config{defaultS3Session: sess})
+
+			if failPart != 0 {
+				if !diags.HasError() {
+					t.Fatal("expected upload to fail")
+				}
+				if !aborted || completed {
+					t.Fatalf("failed multipart upload is not aborted: aborted %t, completed %t", aborted, completed)
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("failed to upload object: %v", diags)
+			}
+			if !completed || aborted {
+				t.Fatalf("multipart upload is not completed: aborted %t, completed %t", aborted, completed)
+			}
+			if expected := map[string]int{"1": 5 << 20, "2": 1 << 20}; !reflect.DeepEqual(expected, parts) {
+				t.Fatalf("unexpected parts uploaded: %v", parts)
+			}
+			if etag := d.Get("etag"); etag != "multipart-2" {
+				t.Fatalf("unexpected etag: %s", etag)
+			}
+		})
+	}
+}
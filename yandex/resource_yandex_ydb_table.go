@@ -28,7 +28,7 @@ func resourceYandexYDBTable() *schema.Resource {
 func resourceYandexYDBTableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cb := func(ctx context.Context) (auth.YdbCredentials, error) {
 		config := meta.(*Config)
-		token, err := config.sdk.CreateIAMToken(ctx)
+		token, err := config.createIAMToken(ctx)
 		if err != nil {
 			return auth.YdbCredentials{}, err
 		}
@@ -40,7 +40,7 @@ func resourceYandexYDBTableCreate(ctx context.Context, d *schema.ResourceData, m
 func resourceYandexYDBTableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cb := func(ctx context.Context) (auth.YdbCredentials, error) {
 		config := meta.(*Config)
-		token, err := config.sdk.CreateIAMToken(ctx)
+		token, err := config.createIAMToken(ctx)
 		if err != nil {
 			return auth.YdbCredentials{}, err
 		}
@@ -52,7 +52,7 @@ func resourceYandexYDBTableRead(ctx context.Context, d *schema.ResourceData, met
 func resourceYandexYDBTableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cb := func(ctx context.Context) (auth.YdbCredentials, error) {
 		config := meta.(*Config)
-		token, err := config.sdk.CreateIAMToken(ctx)
+		token, err := config.createIAMToken(ctx)
 		if err != nil {
 			return auth.YdbCredentials{}, err
 		}
@@ -64,7 +64,7 @@ func resourceYandexYDBTableUpdate(ctx context.Context, d *schema.ResourceData, m
 func resourceYandexYDBTableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cb := func(ctx context.Context) (auth.YdbCredentials, error) {
 		config := meta.(*Config)
-		token, err := config.sdk.CreateIAMToken(ctx)
+		token, err := config.createIAMToken(ctx)
 		if err != nil {
 			return auth.YdbCredentials{}, err
 		}
@@ -39,7 +39,7 @@ func resourceYandexYDBTopic() *schema.Resource {
 func resourceYandexYDBTopicCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cb := func(ctx context.Context) (auth.YdbCredentials, error) {
 		config := meta.(*Config)
-		token, err := config.sdk.CreateIAMToken(ctx)
+		token, err := config.createIAMToken(ctx)
 		if err != nil {
 			return auth.YdbCredentials{}, err
 		}
@@ -51,7 +51,7 @@ func resourceYandexYDBTopicCreate(ctx context.Context, d *schema.ResourceData, m
 func resourceYandexYDBTopicRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cb := func(ctx context.Context) (auth.YdbCredentials, error) {
 		config := meta.(*Config)
-		token, err := config.sdk.CreateIAMToken(ctx)
+		token, err := config.createIAMToken(ctx)
 		if err != nil {
 			return auth.YdbCredentials{}, err
 		}
@@ -63,7 +63,7 @@ func resourceYandexYDBTopicRead(ctx context.Context, d *schema.ResourceData, met
 func resourceYandexYDBTopicUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cb := func(ctx context.Context) (auth.YdbCredentials, error) {
 		config := meta.(*Config)
-		token, err := config.sdk.CreateIAMToken(ctx)
+		token, err := config.createIAMToken(ctx)
 		if err != nil {
 			return auth.YdbCredentials{}, err
 		}
@@ -75,7 +75,7 @@ func resourceYandexYDBTopicUpdate(ctx context.Context, d *schema.ResourceData, m
 func resourceYandexYDBTopicDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cb := func(ctx context.Context) (auth.YdbCredentials, error) {
 		config := meta.(*Config)
-		token, err := config.sdk.CreateIAMToken(ctx)
+		token, err := config.createIAMToken(ctx)
 		if err != nil {
 			return auth.YdbCredentials{}, err
 		}
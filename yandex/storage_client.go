@@ -3,6 +3,7 @@ package yandex
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -10,20 +11,32 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/logging"
 )
 
 const defaultS3Region = "ru-central1"
 
+// Names S3-compatible API calls are rate limited under. Object Storage shares the name with its gRPC API.
+const (
+	storageServiceName = "storage"
+	ymqServiceName     = "ymq"
+)
+
 func getS3ClientByKeys(ctx context.Context, accessKey, secretKey string, c *Config) (*s3.S3, error) {
 	if accessKey == "" || secretKey == "" {
-		if c.defaultS3Session == nil {
-			return nil, fmt.Errorf("failed to get default storage client")
+		if c.defaultS3Session != nil {
+			return newS3Client(ctx, c.defaultS3Session), nil
 		}
 
-		return newS3Client(ctx, c.defaultS3Session), nil
+		var err error
+		accessKey, secretKey, err = c.temporaryStaticAccessKeys.get(ctx, temporaryStorageKeyRoleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default storage client: %w", err)
+		}
 	}
 
-	newSession, err := newS3Session(c.StorageEndpoint, accessKey, secretKey)
+	newSession, err := newS3Session(c.StorageEndpoint, accessKey, secretKey, c.httpClient(storageServiceName, logging.AuditProtocolS3))
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +91,7 @@ func getS3Keys(b *schema.ResourceData) (accessKey, secretKey string, err error)
 	return accessKey, secretKey, nil
 }
 
-func newS3Session(url, accessKey, secretKey string) (*session.Session, error) {
+func newS3Session(url, accessKey, secretKey string, httpClient *http.Client) (*session.Session, error) {
 	if url == "" {
 		return nil, fmt.Errorf("failed to create storage client, endpoint url is not specified")
 	}
@@ -88,6 +101,9 @@ func newS3Session(url, accessKey, secretKey string) (*session.Session, error) {
 		Endpoint:    aws.String(url),
 		Region:      aws.String(defaultS3Region),
 	}
+	if httpClient != nil {
+		s3Config.HTTPClient = httpClient
+	}
 
 	newSession, err := session.NewSession(s3Config)
 	if err != nil {
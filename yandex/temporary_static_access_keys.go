@@ -0,0 +1,109 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// Roles of the temporary service accounts, which access keys are minted for when neither a resource
+// nor the provider has static keys for S3-compatible API of the service.
+const (
+	temporaryStorageKeyRoleID = "storage.admin"
+	temporaryYMQKeyRoleID     = "ymq.admin"
+)
+
+type temporaryStaticAccessKey struct {
+	accessKey string
+	secretKey string
+	cleanup   func()
+}
+
+// temporaryStaticAccessKeys mints temporary static access keys once per role, if it is allowed by
+// allow_temporary_access_keys of the provider, and shares them between all resources of the provider for the whole run.
+type temporaryStaticAccessKeys struct {
+	allowed bool
+	mint    func(ctx context.Context, roleID string) (accessKey, secretKey string, cleanup func(), err error)
+
+	mu   sync.Mutex
+	keys map[string]*temporaryStaticAccessKey
+}
+
+var (
+	temporaryStaticAccessKeysMu      sync.Mutex
+	temporaryStaticAccessKeysCreated []*temporaryStaticAccessKeys
+)
+
+func newTemporaryStaticAccessKeys(config *Config) *temporaryStaticAccessKeys {
+	keys := &temporaryStaticAccessKeys{
+		allowed: config.AllowTemporaryAccessKeys,
+		mint: func(ctx context.Context, roleID string) (string, string, func(), error) {
+			return createTemporaryStaticAccessKey(ctx, roleID, config)
+		},
+		keys: make(map[string]*temporaryStaticAccessKey),
+	}
+
+	temporaryStaticAccessKeysMu.Lock()
+	defer temporaryStaticAccessKeysMu.Unlock()
+	temporaryStaticAccessKeysCreated = append(temporaryStaticAccessKeysCreated, keys)
+
+	return keys
+}
+
+func (k *temporaryStaticAccessKeys) isAllowed() bool {
+	return k != nil && k.allowed
+}
+
+// get returns keys of a temporary service account with the role in the provider folder, minting them on first use.
+func (k *temporaryStaticAccessKeys) get(ctx context.Context, roleID string) (accessKey, secretKey string, err error) {
+	if !k.isAllowed() {
+		return "", "", fmt.Errorf("temporary static access keys are not allowed, set allow_temporary_access_keys in the provider to use them")
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if key, ok := k.keys[roleID]; ok {
+		return key.accessKey, key.secretKey, nil
+	}
+
+	log.Printf("[INFO] Creating temporary static access key with role %q", roleID)
+	accessKey, secretKey, cleanup, err := k.mint(ctx, roleID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temporary static access key with role %q: %w", roleID, err)
+	}
+	k.keys[roleID] = &temporaryStaticAccessKey{
+		accessKey: accessKey,
+		secretKey: secretKey,
+		cleanup:   cleanup,
+	}
+
+	return accessKey, secretKey, nil
+}
+
+func (k *temporaryStaticAccessKeys) release() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for roleID, key := range k.keys {
+		log.Printf("[INFO] Deleting temporary static access key with role %q", roleID)
+		if key.cleanup != nil {
+			key.cleanup()
+		}
+		delete(k.keys, roleID)
+	}
+}
+
+// ReleaseTemporaryStaticAccessKeys deletes temporary static access keys and their service accounts
+// created during the run. It should be called before the provider process exits.
+func ReleaseTemporaryStaticAccessKeys() {
+	temporaryStaticAccessKeysMu.Lock()
+	created := temporaryStaticAccessKeysCreated
+	temporaryStaticAccessKeysCreated = nil
+	temporaryStaticAccessKeysMu.Unlock()
+
+	for _, keys := range created {
+		keys.release()
+	}
+}
@@ -0,0 +1,100 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+type temporaryStaticAccessKeysContextKey struct{}
+
+func TestTemporaryStaticAccessKeys(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		minted   = map[string]int{}
+		released = map[string]int{}
+	)
+	keys := &temporaryStaticAccessKeys{
+		allowed: true,
+		mint: func(ctx context.Context, roleID string) (string, string, func(), error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if ctx.Value(temporaryStaticAccessKeysContextKey{}) == nil {
+				t.Errorf("key with role %s is minted without the request context", roleID)
+			}
+			minted[roleID]++
+			return "ak-" + roleID, "sk-" + roleID, func() { released[roleID]++ }, nil
+		},
+		keys: make(map[string]*temporaryStaticAccessKey),
+	}
+	ctx := context.WithValue(context.Background(), temporaryStaticAccessKeysContextKey{}, true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for _, roleID := range []string{temporaryStorageKeyRoleID, temporaryYMQKeyRoleID} {
+			wg.Add(1)
+			go func(roleID string) {
+				defer wg.Done()
+				accessKey, secretKey, err := keys.get(ctx, roleID)
+				if err != nil {
+					t.Errorf("failed to get key: %s", err)
+					return
+				}
+				if accessKey != "ak-"+roleID || secretKey != "sk-"+roleID {
+					t.Errorf("unexpected key for role %s: %s, %s", roleID, accessKey, secretKey)
+				}
+			}(roleID)
+		}
+	}
+	wg.Wait()
+
+	for _, roleID := range []string{temporaryStorageKeyRoleID, temporaryYMQKeyRoleID} {
+		if minted[roleID] != 1 {
+			t.Errorf("key with role %s is minted %d times", roleID, minted[roleID])
+		}
+		if released[roleID] != 0 {
+			t.Errorf("key with role %s is released before the provider exits", roleID)
+		}
+	}
+
+	keys.release()
+	keys.release()
+	for _, roleID := range []string{temporaryStorageKeyRoleID, temporaryYMQKeyRoleID} {
+		if released[roleID] != 1 {
+			t.Errorf("key with role %s is released %d times", roleID, released[roleID])
+		}
+	}
+}
+
+func TestTemporaryStaticAccessKeysError(t *testing.T) {
+	attempts := 0
+	keys := &temporaryStaticAccessKeys{
+		allowed: true,
+		mint: func(ctx context.Context, roleID string) (string, string, func(), error) {
+			attempts++
+			if attempts == 1 {
+				return "", "", nil, fmt.Errorf("permission denied")
+			}
+			return "ak", "sk", nil, nil
+		},
+		keys: make(map[string]*temporaryStaticAccessKey),
+	}
+
+	if _, _, err := keys.get(context.Background(), temporaryStorageKeyRoleID); err == nil {
+		t.Fatalf("expected error on first attempt")
+	}
+	// Failures are not cached, so the next resource tries again
+	if accessKey, _, err := keys.get(context.Background(), temporaryStorageKeyRoleID); err != nil || accessKey != "ak" {
+		t.Fatalf("unexpected result of second attempt: %q, %v", accessKey, err)
+	}
+
+	for _, notAllowed := range []*temporaryStaticAccessKeys{nil, {mint: keys.mint, keys: make(map[string]*temporaryStaticAccessKey)}} {
+		if notAllowed.isAllowed() {
+			t.Errorf("temporary keys must be allowed explicitly")
+		}
+		if _, _, err := notAllowed.get(context.Background(), temporaryStorageKeyRoleID); err == nil {
+			t.Fatalf("expected error without allow_temporary_access_keys")
+		}
+	}
+}
@@ -135,8 +135,10 @@ func lockCloudByFolderID(config *Config, folderID string) (func(), error) {
 	}, nil
 }
 
-func createTemporaryStaticAccessKey(roleID string, config *Config) (accessKey, secretKey string, cleanup func(), err error) {
-	op, err := config.sdk.WrapOperation(config.sdk.IAM().ServiceAccount().Create(context.Background(), &iam.CreateServiceAccountRequest{
+// createTemporaryStaticAccessKey creates a service account with the role in the provider folder and its static access key.
+// cleanup deletes both of them, it is called when the provider exits, so it doesn't depend on the request context.
+func createTemporaryStaticAccessKey(ctx context.Context, roleID string, config *Config) (accessKey, secretKey string, cleanup func(), err error) {
+	op, err := config.sdk.WrapOperation(config.sdk.IAM().ServiceAccount().Create(ctx, &iam.CreateServiceAccountRequest{
 		FolderId: config.FolderID,
 		Name:     acctest.RandomWithPrefix("tmp-sa-"),
 	}))
@@ -157,7 +159,7 @@ func createTemporaryStaticAccessKey(roleID string, config *Config) (accessKey, s
 
 	saID := md.ServiceAccountId
 
-	err = op.Wait(context.Background())
+	err = op.Wait(ctx)
 	if err != nil {
 		return
 	}
@@ -175,7 +177,7 @@ func createTemporaryStaticAccessKey(roleID string, config *Config) (accessKey, s
 		mutexKV.Lock(mutexKey)
 		defer mutexKV.Unlock(mutexKey)
 
-		op, err := config.sdk.WrapOperation(config.sdk.IAM().ServiceAccount().Delete(context.Background(), &iam.DeleteServiceAccountRequest{
+		op, err := config.sdk.WrapOperation(config.sdk.IAM().ServiceAccount().Delete(config.Context(), &iam.DeleteServiceAccountRequest{
 			ServiceAccountId: saID,
 		}))
 		if err != nil {
@@ -183,14 +185,14 @@ func createTemporaryStaticAccessKey(roleID string, config *Config) (accessKey, s
 			return
 		}
 
-		err = op.Wait(context.Background())
+		err = op.Wait(config.Context())
 		if err != nil {
 			log.Printf("[WARN] error deleting temporary service account: %s", err)
 		}
 	}
 
 	createKey := func() (*awscompatibility.CreateAccessKeyResponse, error) {
-		op, err = config.sdk.WrapOperation(config.sdk.ResourceManager().Folder().UpdateAccessBindings(context.Background(), &access.UpdateAccessBindingsRequest{
+		op, err = config.sdk.WrapOperation(config.sdk.ResourceManager().Folder().UpdateAccessBindings(ctx, &access.UpdateAccessBindingsRequest{
 			ResourceId: config.FolderID,
 			AccessBindingDeltas: []*access.AccessBindingDelta{
 				{
@@ -209,12 +211,12 @@ func createTemporaryStaticAccessKey(roleID string, config *Config) (accessKey, s
 			return nil, err
 		}
 
-		err = op.Wait(context.Background())
+		err = op.Wait(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		sak, err := config.sdk.IAM().AWSCompatibility().AccessKey().Create(context.Background(), &awscompatibility.CreateAccessKeyRequest{
+		sak, err := config.sdk.IAM().AWSCompatibility().AccessKey().Create(ctx, &awscompatibility.CreateAccessKeyRequest{
 			ServiceAccountId: saID,
 		})
 		if err != nil {
@@ -233,7 +235,7 @@ func createTemporaryStaticAccessKey(roleID string, config *Config) (accessKey, s
 	accessKey = sak.AccessKey.KeyId
 	secretKey = sak.Secret
 	cleanup = func() {
-		_, err := config.sdk.IAM().AWSCompatibility().AccessKey().Delete(context.Background(), &awscompatibility.DeleteAccessKeyRequest{
+		_, err := config.sdk.IAM().AWSCompatibility().AccessKey().Delete(config.Context(), &awscompatibility.DeleteAccessKeyRequest{
 			AccessKeyId: sak.AccessKey.Id,
 		})
 		if err != nil {
@@ -0,0 +1,39 @@
+package yandex
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Values of write-only attributes are sent to the API, but never persisted in plan or state,
+// so they are available only in configuration, and only during apply.
+// Write-only attribute is paired with a version attribute, which is stored in state, so that
+// bumping the version makes a diff and triggers sending the value again.
+
+// getWriteOnlyString returns value of the write-only string attribute, or an empty string if it is not set.
+func getWriteOnlyString(d *schema.ResourceData, path cty.Path) (string, error) {
+	v, diags := d.GetRawConfigAt(path)
+	if diags.HasError() {
+		return "", fmt.Errorf("failed to get write-only attribute value: %s", diags[0].Summary)
+	}
+	if !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+		return "", nil
+	}
+	return v.AsString(), nil
+}
+
+// getMDBUserPassword returns password of MDB user from either "password" or write-only "password_wo" attribute.
+func getMDBUserPassword(d *schema.ResourceData) (string, error) {
+	if v, ok := d.GetOk("password"); ok {
+		return v.(string), nil
+	}
+	return getWriteOnlyString(d, cty.GetAttrPath("password_wo"))
+}
+
+// mdbUserPasswordChanged reports whether password of MDB user should be updated: either "password" has changed,
+// or "password_wo_version" has been bumped.
+func mdbUserPasswordChanged(d *schema.ResourceData) bool {
+	return d.HasChange("password") || d.HasChange("password_wo_version")
+}
@@ -64,6 +64,7 @@ func configForSweepers() (*Config, error) {
 		FolderID:                       folderID,
 		Endpoint:                       os.Getenv("YC_ENDPOINT"),
 		StorageEndpoint:                os.Getenv("YC_STORAGE_ENDPOINT_URL"),
+		ImpersonateServiceAccountID:    os.Getenv("YC_IMPERSONATE_SERVICE_ACCOUNT_ID"),
 	}
 
 	err = conf.initAndValidate(context.Background(), "", true)